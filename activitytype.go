@@ -0,0 +1,33 @@
+package backlog
+
+// ActivityType represents the kind of the activity in the project, which is used by the recent updates and the webhooks.
+type ActivityType int
+
+const (
+	ActivityTypeIssueCreated             ActivityType = 1
+	ActivityTypeIssueUpdated             ActivityType = 2
+	ActivityTypeIssueCommented           ActivityType = 3
+	ActivityTypeIssueDeleted             ActivityType = 4
+	ActivityTypeWikiCreated              ActivityType = 5
+	ActivityTypeWikiUpdated              ActivityType = 6
+	ActivityTypeWikiDeleted              ActivityType = 7
+	ActivityTypeFileAdded                ActivityType = 8
+	ActivityTypeFileUpdated              ActivityType = 9
+	ActivityTypeFileDeleted              ActivityType = 10
+	ActivityTypeSVNCommitted             ActivityType = 11
+	ActivityTypeGitPushed                ActivityType = 12
+	ActivityTypeGitRepositoryCreated     ActivityType = 13
+	ActivityTypeIssueMultiUpdated        ActivityType = 14
+	ActivityTypeProjectUserAdded         ActivityType = 15
+	ActivityTypeProjectUserDeleted       ActivityType = 16
+	ActivityTypeCommentNotificationAdded ActivityType = 17
+	ActivityTypePullRequestAdded         ActivityType = 18
+	ActivityTypePullRequestUpdated       ActivityType = 19
+	ActivityTypePullRequestCommented     ActivityType = 20
+	ActivityTypePullRequestDeleted       ActivityType = 21
+	ActivityTypeMilestoneCreated         ActivityType = 22
+	ActivityTypeMilestoneUpdated         ActivityType = 23
+	ActivityTypeMilestoneDeleted         ActivityType = 24
+	ActivityTypeProjectGroupAdded        ActivityType = 25
+	ActivityTypeProjectGroupDeleted      ActivityType = 26
+)
@@ -1,7 +1,9 @@
 package backlog
 
 type Attachment struct {
-	Id   int    `json:"id"`
-	Name string `json:"name"`
-	Size int    `json:"size"`
+	Id          int    `json:"id"`
+	Name        string `json:"name"`
+	Size        int    `json:"size"`
+	CreatedUser User   `json:"createdUser"`
+	Created     Date   `json:"created"`
 }
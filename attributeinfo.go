@@ -0,0 +1,6 @@
+package backlog
+
+type AttributeInfo struct {
+	Id     int `json:"id"`
+	TypeId int `json:"typeId"`
+}
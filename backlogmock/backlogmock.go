@@ -0,0 +1,1449 @@
+// Code generated by genmock from services.go. DO NOT EDIT.
+
+// Package backlogmock provides the mocks of the services of the backlog package. Each method calls the function in the field of the same name with the suffix Func, and panics if the field is nil.
+//
+//	issues := &backlogmock.IssueService{
+//		GetIssueFunc: func(issueId string) (*backlog.Issue, error) {
+//			return &backlog.Issue{IssueKey: issueId}, nil
+//		},
+//	}
+package backlogmock
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"time"
+
+	backlog "github.com/moutend/go-backlog"
+)
+
+// ProjectService is the mock of backlog.ProjectService.
+type ProjectService struct {
+	GetProjectsFunc          func(query url.Values) ([]*backlog.Project, error)
+	GetProjectsContextFunc   func(ctx context.Context, query url.Values) ([]*backlog.Project, error)
+	GetIssueTypesFunc        func(projectId int) ([]*backlog.IssueType, error)
+	GetIssueTypesContextFunc func(ctx context.Context, projectId int) ([]*backlog.IssueType, error)
+}
+
+func (m *ProjectService) GetProjects(query url.Values) ([]*backlog.Project, error) {
+	if m.GetProjectsFunc == nil {
+		panic("backlogmock: ProjectService.GetProjectsFunc is not set")
+	}
+
+	return m.GetProjectsFunc(query)
+}
+
+func (m *ProjectService) GetProjectsContext(ctx context.Context, query url.Values) ([]*backlog.Project, error) {
+	if m.GetProjectsContextFunc == nil {
+		panic("backlogmock: ProjectService.GetProjectsContextFunc is not set")
+	}
+
+	return m.GetProjectsContextFunc(ctx, query)
+}
+
+func (m *ProjectService) GetIssueTypes(projectId int) ([]*backlog.IssueType, error) {
+	if m.GetIssueTypesFunc == nil {
+		panic("backlogmock: ProjectService.GetIssueTypesFunc is not set")
+	}
+
+	return m.GetIssueTypesFunc(projectId)
+}
+
+func (m *ProjectService) GetIssueTypesContext(ctx context.Context, projectId int) ([]*backlog.IssueType, error) {
+	if m.GetIssueTypesContextFunc == nil {
+		panic("backlogmock: ProjectService.GetIssueTypesContextFunc is not set")
+	}
+
+	return m.GetIssueTypesContextFunc(ctx, projectId)
+}
+
+// IssueService is the mock of backlog.IssueService.
+type IssueService struct {
+	GetIssuesFunc              func(query url.Values) ([]*backlog.Issue, error)
+	GetIssuesContextFunc       func(ctx context.Context, query url.Values) ([]*backlog.Issue, error)
+	GetIssuesCountFunc         func(query url.Values) (int, error)
+	GetIssuesCountContextFunc  func(ctx context.Context, query url.Values) (int, error)
+	GetIssueFunc               func(issueId string) (*backlog.Issue, error)
+	GetIssueContextFunc        func(ctx context.Context, issueId string) (*backlog.Issue, error)
+	CreateIssueFunc            func(values url.Values) (*backlog.Issue, error)
+	CreateIssueContextFunc     func(ctx context.Context, values url.Values) (*backlog.Issue, error)
+	SetIssueFunc               func(issueId string, values url.Values) (*backlog.Issue, error)
+	SetIssueContextFunc        func(ctx context.Context, issueId string, values url.Values) (*backlog.Issue, error)
+	DeleteIssueFunc            func(issueId int) (*backlog.Issue, error)
+	DeleteIssueContextFunc     func(ctx context.Context, issueId int) (*backlog.Issue, error)
+	GetIssueHistoryFunc        func(issueId string) (*backlog.IssueHistory, error)
+	GetIssueHistoryContextFunc func(ctx context.Context, issueId string) (*backlog.IssueHistory, error)
+}
+
+func (m *IssueService) GetIssues(query url.Values) ([]*backlog.Issue, error) {
+	if m.GetIssuesFunc == nil {
+		panic("backlogmock: IssueService.GetIssuesFunc is not set")
+	}
+
+	return m.GetIssuesFunc(query)
+}
+
+func (m *IssueService) GetIssuesContext(ctx context.Context, query url.Values) ([]*backlog.Issue, error) {
+	if m.GetIssuesContextFunc == nil {
+		panic("backlogmock: IssueService.GetIssuesContextFunc is not set")
+	}
+
+	return m.GetIssuesContextFunc(ctx, query)
+}
+
+func (m *IssueService) GetIssuesCount(query url.Values) (int, error) {
+	if m.GetIssuesCountFunc == nil {
+		panic("backlogmock: IssueService.GetIssuesCountFunc is not set")
+	}
+
+	return m.GetIssuesCountFunc(query)
+}
+
+func (m *IssueService) GetIssuesCountContext(ctx context.Context, query url.Values) (int, error) {
+	if m.GetIssuesCountContextFunc == nil {
+		panic("backlogmock: IssueService.GetIssuesCountContextFunc is not set")
+	}
+
+	return m.GetIssuesCountContextFunc(ctx, query)
+}
+
+func (m *IssueService) GetIssue(issueId string) (*backlog.Issue, error) {
+	if m.GetIssueFunc == nil {
+		panic("backlogmock: IssueService.GetIssueFunc is not set")
+	}
+
+	return m.GetIssueFunc(issueId)
+}
+
+func (m *IssueService) GetIssueContext(ctx context.Context, issueId string) (*backlog.Issue, error) {
+	if m.GetIssueContextFunc == nil {
+		panic("backlogmock: IssueService.GetIssueContextFunc is not set")
+	}
+
+	return m.GetIssueContextFunc(ctx, issueId)
+}
+
+func (m *IssueService) CreateIssue(values url.Values) (*backlog.Issue, error) {
+	if m.CreateIssueFunc == nil {
+		panic("backlogmock: IssueService.CreateIssueFunc is not set")
+	}
+
+	return m.CreateIssueFunc(values)
+}
+
+func (m *IssueService) CreateIssueContext(ctx context.Context, values url.Values) (*backlog.Issue, error) {
+	if m.CreateIssueContextFunc == nil {
+		panic("backlogmock: IssueService.CreateIssueContextFunc is not set")
+	}
+
+	return m.CreateIssueContextFunc(ctx, values)
+}
+
+func (m *IssueService) SetIssue(issueId string, values url.Values) (*backlog.Issue, error) {
+	if m.SetIssueFunc == nil {
+		panic("backlogmock: IssueService.SetIssueFunc is not set")
+	}
+
+	return m.SetIssueFunc(issueId, values)
+}
+
+func (m *IssueService) SetIssueContext(ctx context.Context, issueId string, values url.Values) (*backlog.Issue, error) {
+	if m.SetIssueContextFunc == nil {
+		panic("backlogmock: IssueService.SetIssueContextFunc is not set")
+	}
+
+	return m.SetIssueContextFunc(ctx, issueId, values)
+}
+
+func (m *IssueService) DeleteIssue(issueId int) (*backlog.Issue, error) {
+	if m.DeleteIssueFunc == nil {
+		panic("backlogmock: IssueService.DeleteIssueFunc is not set")
+	}
+
+	return m.DeleteIssueFunc(issueId)
+}
+
+func (m *IssueService) DeleteIssueContext(ctx context.Context, issueId int) (*backlog.Issue, error) {
+	if m.DeleteIssueContextFunc == nil {
+		panic("backlogmock: IssueService.DeleteIssueContextFunc is not set")
+	}
+
+	return m.DeleteIssueContextFunc(ctx, issueId)
+}
+
+func (m *IssueService) GetIssueHistory(issueId string) (*backlog.IssueHistory, error) {
+	if m.GetIssueHistoryFunc == nil {
+		panic("backlogmock: IssueService.GetIssueHistoryFunc is not set")
+	}
+
+	return m.GetIssueHistoryFunc(issueId)
+}
+
+func (m *IssueService) GetIssueHistoryContext(ctx context.Context, issueId string) (*backlog.IssueHistory, error) {
+	if m.GetIssueHistoryContextFunc == nil {
+		panic("backlogmock: IssueService.GetIssueHistoryContextFunc is not set")
+	}
+
+	return m.GetIssueHistoryContextFunc(ctx, issueId)
+}
+
+// CommentService is the mock of backlog.CommentService.
+type CommentService struct {
+	GetCommentsFunc                    func(issueId string, values url.Values) ([]*backlog.Comment, error)
+	GetCommentsContextFunc             func(ctx context.Context, issueId string, values url.Values) ([]*backlog.Comment, error)
+	GetCommentsCountFunc               func(issueId string) (int, error)
+	GetCommentsCountContextFunc        func(ctx context.Context, issueId string) (int, error)
+	GetCommentFunc                     func(issueId string, commentId int) (*backlog.Comment, error)
+	GetCommentContextFunc              func(ctx context.Context, issueId string, commentId int) (*backlog.Comment, error)
+	AddCommentFunc                     func(issueId, content string, notifiedUserIds, attachmentIds []int) (*backlog.Comment, error)
+	AddCommentContextFunc              func(ctx context.Context, issueId, content string, notifiedUserIds, attachmentIds []int) (*backlog.Comment, error)
+	UpdateCommentFunc                  func(issueId string, commentId int, content string) (*backlog.Comment, error)
+	UpdateCommentContextFunc           func(ctx context.Context, issueId string, commentId int, content string) (*backlog.Comment, error)
+	DeleteCommentFunc                  func(issueId string, commentId int) (*backlog.Comment, error)
+	DeleteCommentContextFunc           func(ctx context.Context, issueId string, commentId int) (*backlog.Comment, error)
+	GetCommentNotificationsFunc        func(issueId string, commentId int) ([]*backlog.Notification, error)
+	GetCommentNotificationsContextFunc func(ctx context.Context, issueId string, commentId int) ([]*backlog.Notification, error)
+	AddCommentNotificationFunc         func(issueId string, commentId int, notifiedUserIds []int) (*backlog.Comment, error)
+	AddCommentNotificationContextFunc  func(ctx context.Context, issueId string, commentId int, notifiedUserIds []int) (*backlog.Comment, error)
+}
+
+func (m *CommentService) GetComments(issueId string, values url.Values) ([]*backlog.Comment, error) {
+	if m.GetCommentsFunc == nil {
+		panic("backlogmock: CommentService.GetCommentsFunc is not set")
+	}
+
+	return m.GetCommentsFunc(issueId, values)
+}
+
+func (m *CommentService) GetCommentsContext(ctx context.Context, issueId string, values url.Values) ([]*backlog.Comment, error) {
+	if m.GetCommentsContextFunc == nil {
+		panic("backlogmock: CommentService.GetCommentsContextFunc is not set")
+	}
+
+	return m.GetCommentsContextFunc(ctx, issueId, values)
+}
+
+func (m *CommentService) GetCommentsCount(issueId string) (int, error) {
+	if m.GetCommentsCountFunc == nil {
+		panic("backlogmock: CommentService.GetCommentsCountFunc is not set")
+	}
+
+	return m.GetCommentsCountFunc(issueId)
+}
+
+func (m *CommentService) GetCommentsCountContext(ctx context.Context, issueId string) (int, error) {
+	if m.GetCommentsCountContextFunc == nil {
+		panic("backlogmock: CommentService.GetCommentsCountContextFunc is not set")
+	}
+
+	return m.GetCommentsCountContextFunc(ctx, issueId)
+}
+
+func (m *CommentService) GetComment(issueId string, commentId int) (*backlog.Comment, error) {
+	if m.GetCommentFunc == nil {
+		panic("backlogmock: CommentService.GetCommentFunc is not set")
+	}
+
+	return m.GetCommentFunc(issueId, commentId)
+}
+
+func (m *CommentService) GetCommentContext(ctx context.Context, issueId string, commentId int) (*backlog.Comment, error) {
+	if m.GetCommentContextFunc == nil {
+		panic("backlogmock: CommentService.GetCommentContextFunc is not set")
+	}
+
+	return m.GetCommentContextFunc(ctx, issueId, commentId)
+}
+
+func (m *CommentService) AddComment(issueId, content string, notifiedUserIds, attachmentIds []int) (*backlog.Comment, error) {
+	if m.AddCommentFunc == nil {
+		panic("backlogmock: CommentService.AddCommentFunc is not set")
+	}
+
+	return m.AddCommentFunc(issueId, content, notifiedUserIds, attachmentIds)
+}
+
+func (m *CommentService) AddCommentContext(ctx context.Context, issueId, content string, notifiedUserIds, attachmentIds []int) (*backlog.Comment, error) {
+	if m.AddCommentContextFunc == nil {
+		panic("backlogmock: CommentService.AddCommentContextFunc is not set")
+	}
+
+	return m.AddCommentContextFunc(ctx, issueId, content, notifiedUserIds, attachmentIds)
+}
+
+func (m *CommentService) UpdateComment(issueId string, commentId int, content string) (*backlog.Comment, error) {
+	if m.UpdateCommentFunc == nil {
+		panic("backlogmock: CommentService.UpdateCommentFunc is not set")
+	}
+
+	return m.UpdateCommentFunc(issueId, commentId, content)
+}
+
+func (m *CommentService) UpdateCommentContext(ctx context.Context, issueId string, commentId int, content string) (*backlog.Comment, error) {
+	if m.UpdateCommentContextFunc == nil {
+		panic("backlogmock: CommentService.UpdateCommentContextFunc is not set")
+	}
+
+	return m.UpdateCommentContextFunc(ctx, issueId, commentId, content)
+}
+
+func (m *CommentService) DeleteComment(issueId string, commentId int) (*backlog.Comment, error) {
+	if m.DeleteCommentFunc == nil {
+		panic("backlogmock: CommentService.DeleteCommentFunc is not set")
+	}
+
+	return m.DeleteCommentFunc(issueId, commentId)
+}
+
+func (m *CommentService) DeleteCommentContext(ctx context.Context, issueId string, commentId int) (*backlog.Comment, error) {
+	if m.DeleteCommentContextFunc == nil {
+		panic("backlogmock: CommentService.DeleteCommentContextFunc is not set")
+	}
+
+	return m.DeleteCommentContextFunc(ctx, issueId, commentId)
+}
+
+func (m *CommentService) GetCommentNotifications(issueId string, commentId int) ([]*backlog.Notification, error) {
+	if m.GetCommentNotificationsFunc == nil {
+		panic("backlogmock: CommentService.GetCommentNotificationsFunc is not set")
+	}
+
+	return m.GetCommentNotificationsFunc(issueId, commentId)
+}
+
+func (m *CommentService) GetCommentNotificationsContext(ctx context.Context, issueId string, commentId int) ([]*backlog.Notification, error) {
+	if m.GetCommentNotificationsContextFunc == nil {
+		panic("backlogmock: CommentService.GetCommentNotificationsContextFunc is not set")
+	}
+
+	return m.GetCommentNotificationsContextFunc(ctx, issueId, commentId)
+}
+
+func (m *CommentService) AddCommentNotification(issueId string, commentId int, notifiedUserIds []int) (*backlog.Comment, error) {
+	if m.AddCommentNotificationFunc == nil {
+		panic("backlogmock: CommentService.AddCommentNotificationFunc is not set")
+	}
+
+	return m.AddCommentNotificationFunc(issueId, commentId, notifiedUserIds)
+}
+
+func (m *CommentService) AddCommentNotificationContext(ctx context.Context, issueId string, commentId int, notifiedUserIds []int) (*backlog.Comment, error) {
+	if m.AddCommentNotificationContextFunc == nil {
+		panic("backlogmock: CommentService.AddCommentNotificationContextFunc is not set")
+	}
+
+	return m.AddCommentNotificationContextFunc(ctx, issueId, commentId, notifiedUserIds)
+}
+
+// UserService is the mock of backlog.UserService.
+type UserService struct {
+	GetMyselfFunc        func() (*backlog.User, error)
+	GetMyselfContextFunc func(ctx context.Context) (*backlog.User, error)
+	GetUsersFunc         func() ([]*backlog.User, error)
+	GetUsersContextFunc  func(ctx context.Context) ([]*backlog.User, error)
+}
+
+func (m *UserService) GetMyself() (*backlog.User, error) {
+	if m.GetMyselfFunc == nil {
+		panic("backlogmock: UserService.GetMyselfFunc is not set")
+	}
+
+	return m.GetMyselfFunc()
+}
+
+func (m *UserService) GetMyselfContext(ctx context.Context) (*backlog.User, error) {
+	if m.GetMyselfContextFunc == nil {
+		panic("backlogmock: UserService.GetMyselfContextFunc is not set")
+	}
+
+	return m.GetMyselfContextFunc(ctx)
+}
+
+func (m *UserService) GetUsers() ([]*backlog.User, error) {
+	if m.GetUsersFunc == nil {
+		panic("backlogmock: UserService.GetUsersFunc is not set")
+	}
+
+	return m.GetUsersFunc()
+}
+
+func (m *UserService) GetUsersContext(ctx context.Context) ([]*backlog.User, error) {
+	if m.GetUsersContextFunc == nil {
+		panic("backlogmock: UserService.GetUsersContextFunc is not set")
+	}
+
+	return m.GetUsersContextFunc(ctx)
+}
+
+// SpaceService is the mock of backlog.SpaceService.
+type SpaceService struct {
+	GetStatusesFunc             func() ([]*backlog.Status, error)
+	GetStatusesContextFunc      func(ctx context.Context) ([]*backlog.Status, error)
+	GetPrioritiesFunc           func() ([]*backlog.Priority, error)
+	GetPrioritiesContextFunc    func(ctx context.Context) ([]*backlog.Priority, error)
+	GetLicenceFunc              func() (*backlog.Licence, error)
+	GetLicenceContextFunc       func(ctx context.Context) (*backlog.Licence, error)
+	GetRateLimitFunc            func() (*backlog.RateLimit, error)
+	GetRateLimitContextFunc     func(ctx context.Context) (*backlog.RateLimit, error)
+	GetCapabilitiesFunc         func() (backlog.Capabilities, error)
+	GetCapabilitiesContextFunc  func(ctx context.Context) (backlog.Capabilities, error)
+	UploadAttachmentFunc        func(name string, file io.Reader) (*backlog.Attachment, error)
+	UploadAttachmentContextFunc func(ctx context.Context, name string, file io.Reader) (*backlog.Attachment, error)
+}
+
+func (m *SpaceService) GetStatuses() ([]*backlog.Status, error) {
+	if m.GetStatusesFunc == nil {
+		panic("backlogmock: SpaceService.GetStatusesFunc is not set")
+	}
+
+	return m.GetStatusesFunc()
+}
+
+func (m *SpaceService) GetStatusesContext(ctx context.Context) ([]*backlog.Status, error) {
+	if m.GetStatusesContextFunc == nil {
+		panic("backlogmock: SpaceService.GetStatusesContextFunc is not set")
+	}
+
+	return m.GetStatusesContextFunc(ctx)
+}
+
+func (m *SpaceService) GetPriorities() ([]*backlog.Priority, error) {
+	if m.GetPrioritiesFunc == nil {
+		panic("backlogmock: SpaceService.GetPrioritiesFunc is not set")
+	}
+
+	return m.GetPrioritiesFunc()
+}
+
+func (m *SpaceService) GetPrioritiesContext(ctx context.Context) ([]*backlog.Priority, error) {
+	if m.GetPrioritiesContextFunc == nil {
+		panic("backlogmock: SpaceService.GetPrioritiesContextFunc is not set")
+	}
+
+	return m.GetPrioritiesContextFunc(ctx)
+}
+
+func (m *SpaceService) GetLicence() (*backlog.Licence, error) {
+	if m.GetLicenceFunc == nil {
+		panic("backlogmock: SpaceService.GetLicenceFunc is not set")
+	}
+
+	return m.GetLicenceFunc()
+}
+
+func (m *SpaceService) GetLicenceContext(ctx context.Context) (*backlog.Licence, error) {
+	if m.GetLicenceContextFunc == nil {
+		panic("backlogmock: SpaceService.GetLicenceContextFunc is not set")
+	}
+
+	return m.GetLicenceContextFunc(ctx)
+}
+
+func (m *SpaceService) GetRateLimit() (*backlog.RateLimit, error) {
+	if m.GetRateLimitFunc == nil {
+		panic("backlogmock: SpaceService.GetRateLimitFunc is not set")
+	}
+
+	return m.GetRateLimitFunc()
+}
+
+func (m *SpaceService) GetRateLimitContext(ctx context.Context) (*backlog.RateLimit, error) {
+	if m.GetRateLimitContextFunc == nil {
+		panic("backlogmock: SpaceService.GetRateLimitContextFunc is not set")
+	}
+
+	return m.GetRateLimitContextFunc(ctx)
+}
+
+func (m *SpaceService) GetCapabilities() (backlog.Capabilities, error) {
+	if m.GetCapabilitiesFunc == nil {
+		panic("backlogmock: SpaceService.GetCapabilitiesFunc is not set")
+	}
+
+	return m.GetCapabilitiesFunc()
+}
+
+func (m *SpaceService) GetCapabilitiesContext(ctx context.Context) (backlog.Capabilities, error) {
+	if m.GetCapabilitiesContextFunc == nil {
+		panic("backlogmock: SpaceService.GetCapabilitiesContextFunc is not set")
+	}
+
+	return m.GetCapabilitiesContextFunc(ctx)
+}
+
+func (m *SpaceService) UploadAttachment(name string, file io.Reader) (*backlog.Attachment, error) {
+	if m.UploadAttachmentFunc == nil {
+		panic("backlogmock: SpaceService.UploadAttachmentFunc is not set")
+	}
+
+	return m.UploadAttachmentFunc(name, file)
+}
+
+func (m *SpaceService) UploadAttachmentContext(ctx context.Context, name string, file io.Reader) (*backlog.Attachment, error) {
+	if m.UploadAttachmentContextFunc == nil {
+		panic("backlogmock: SpaceService.UploadAttachmentContextFunc is not set")
+	}
+
+	return m.UploadAttachmentContextFunc(ctx, name, file)
+}
+
+// RepositoryService is the mock of backlog.RepositoryService.
+type RepositoryService struct {
+	GetRepositoriesFunc        func(projectId string, query url.Values) ([]*backlog.Repository, error)
+	GetRepositoriesContextFunc func(ctx context.Context, projectId string, query url.Values) ([]*backlog.Repository, error)
+	GetRepositoryFunc          func(projectId, repositoryId string) (*backlog.Repository, error)
+	GetRepositoryContextFunc   func(ctx context.Context, projectId, repositoryId string) (*backlog.Repository, error)
+}
+
+func (m *RepositoryService) GetRepositories(projectId string, query url.Values) ([]*backlog.Repository, error) {
+	if m.GetRepositoriesFunc == nil {
+		panic("backlogmock: RepositoryService.GetRepositoriesFunc is not set")
+	}
+
+	return m.GetRepositoriesFunc(projectId, query)
+}
+
+func (m *RepositoryService) GetRepositoriesContext(ctx context.Context, projectId string, query url.Values) ([]*backlog.Repository, error) {
+	if m.GetRepositoriesContextFunc == nil {
+		panic("backlogmock: RepositoryService.GetRepositoriesContextFunc is not set")
+	}
+
+	return m.GetRepositoriesContextFunc(ctx, projectId, query)
+}
+
+func (m *RepositoryService) GetRepository(projectId, repositoryId string) (*backlog.Repository, error) {
+	if m.GetRepositoryFunc == nil {
+		panic("backlogmock: RepositoryService.GetRepositoryFunc is not set")
+	}
+
+	return m.GetRepositoryFunc(projectId, repositoryId)
+}
+
+func (m *RepositoryService) GetRepositoryContext(ctx context.Context, projectId, repositoryId string) (*backlog.Repository, error) {
+	if m.GetRepositoryContextFunc == nil {
+		panic("backlogmock: RepositoryService.GetRepositoryContextFunc is not set")
+	}
+
+	return m.GetRepositoryContextFunc(ctx, projectId, repositoryId)
+}
+
+// PullRequestService is the mock of backlog.PullRequestService.
+type PullRequestService struct {
+	GetPullRequestsFunc                      func(projectID, repositoryID string, query url.Values) ([]*backlog.PullRequest, error)
+	GetPullRequestsContextFunc               func(ctx context.Context, projectID, repositoryID string, query url.Values) ([]*backlog.PullRequest, error)
+	GetPullRequestsCountFunc                 func(projectID, repositoryID string, query url.Values) (int, error)
+	GetPullRequestsCountContextFunc          func(ctx context.Context, projectID, repositoryID string, query url.Values) (int, error)
+	GetPullRequestFunc                       func(projectID, repositoryID string, number int, query url.Values) (*backlog.PullRequest, error)
+	GetPullRequestContextFunc                func(ctx context.Context, projectID, repositoryID string, number int, query url.Values) (*backlog.PullRequest, error)
+	CreatePullRequestFunc                    func(projectId, repositoryId string, values url.Values) (*backlog.PullRequest, error)
+	CreatePullRequestContextFunc             func(ctx context.Context, projectId, repositoryId string, values url.Values) (*backlog.PullRequest, error)
+	UpdatePullRequestFunc                    func(projectId, repositoryId string, number int, values url.Values) (*backlog.PullRequest, error)
+	UpdatePullRequestContextFunc             func(ctx context.Context, projectId, repositoryId string, number int, values url.Values) (*backlog.PullRequest, error)
+	GetPullRequestCommentsFunc               func(projectId, repositoryId string, number int, query url.Values) ([]*backlog.PullRequestComment, error)
+	GetPullRequestCommentsContextFunc        func(ctx context.Context, projectId, repositoryId string, number int, query url.Values) ([]*backlog.PullRequestComment, error)
+	GetPullRequestCommentsCountFunc          func(projectId, repositoryId string, number int) (int, error)
+	GetPullRequestCommentsCountContextFunc   func(ctx context.Context, projectId, repositoryId string, number int) (int, error)
+	AddPullRequestCommentFunc                func(projectId, repositoryId string, number int, content string, notifiedUserIds []int) (*backlog.PullRequestComment, error)
+	AddPullRequestCommentContextFunc         func(ctx context.Context, projectId, repositoryId string, number int, content string, notifiedUserIds []int) (*backlog.PullRequestComment, error)
+	UpdatePullRequestCommentFunc             func(projectId, repositoryId string, number, commentId int, content string) (*backlog.PullRequestComment, error)
+	UpdatePullRequestCommentContextFunc      func(ctx context.Context, projectId, repositoryId string, number, commentId int, content string) (*backlog.PullRequestComment, error)
+	GetPullRequestAttachmentsFunc            func(projectId, repositoryId string, number int) ([]*backlog.Attachment, error)
+	GetPullRequestAttachmentsContextFunc     func(ctx context.Context, projectId, repositoryId string, number int) ([]*backlog.Attachment, error)
+	DownloadPullRequestAttachmentFunc        func(projectId, repositoryId string, number, attachmentId int) ([]byte, error)
+	DownloadPullRequestAttachmentContextFunc func(ctx context.Context, projectId, repositoryId string, number, attachmentId int) ([]byte, error)
+	DeletePullRequestAttachmentFunc          func(projectId, repositoryId string, number, attachmentId int) (*backlog.Attachment, error)
+	DeletePullRequestAttachmentContextFunc   func(ctx context.Context, projectId, repositoryId string, number, attachmentId int) (*backlog.Attachment, error)
+}
+
+func (m *PullRequestService) GetPullRequests(projectID, repositoryID string, query url.Values) ([]*backlog.PullRequest, error) {
+	if m.GetPullRequestsFunc == nil {
+		panic("backlogmock: PullRequestService.GetPullRequestsFunc is not set")
+	}
+
+	return m.GetPullRequestsFunc(projectID, repositoryID, query)
+}
+
+func (m *PullRequestService) GetPullRequestsContext(ctx context.Context, projectID, repositoryID string, query url.Values) ([]*backlog.PullRequest, error) {
+	if m.GetPullRequestsContextFunc == nil {
+		panic("backlogmock: PullRequestService.GetPullRequestsContextFunc is not set")
+	}
+
+	return m.GetPullRequestsContextFunc(ctx, projectID, repositoryID, query)
+}
+
+func (m *PullRequestService) GetPullRequestsCount(projectID, repositoryID string, query url.Values) (int, error) {
+	if m.GetPullRequestsCountFunc == nil {
+		panic("backlogmock: PullRequestService.GetPullRequestsCountFunc is not set")
+	}
+
+	return m.GetPullRequestsCountFunc(projectID, repositoryID, query)
+}
+
+func (m *PullRequestService) GetPullRequestsCountContext(ctx context.Context, projectID, repositoryID string, query url.Values) (int, error) {
+	if m.GetPullRequestsCountContextFunc == nil {
+		panic("backlogmock: PullRequestService.GetPullRequestsCountContextFunc is not set")
+	}
+
+	return m.GetPullRequestsCountContextFunc(ctx, projectID, repositoryID, query)
+}
+
+func (m *PullRequestService) GetPullRequest(projectID, repositoryID string, number int, query url.Values) (*backlog.PullRequest, error) {
+	if m.GetPullRequestFunc == nil {
+		panic("backlogmock: PullRequestService.GetPullRequestFunc is not set")
+	}
+
+	return m.GetPullRequestFunc(projectID, repositoryID, number, query)
+}
+
+func (m *PullRequestService) GetPullRequestContext(ctx context.Context, projectID, repositoryID string, number int, query url.Values) (*backlog.PullRequest, error) {
+	if m.GetPullRequestContextFunc == nil {
+		panic("backlogmock: PullRequestService.GetPullRequestContextFunc is not set")
+	}
+
+	return m.GetPullRequestContextFunc(ctx, projectID, repositoryID, number, query)
+}
+
+func (m *PullRequestService) CreatePullRequest(projectId, repositoryId string, values url.Values) (*backlog.PullRequest, error) {
+	if m.CreatePullRequestFunc == nil {
+		panic("backlogmock: PullRequestService.CreatePullRequestFunc is not set")
+	}
+
+	return m.CreatePullRequestFunc(projectId, repositoryId, values)
+}
+
+func (m *PullRequestService) CreatePullRequestContext(ctx context.Context, projectId, repositoryId string, values url.Values) (*backlog.PullRequest, error) {
+	if m.CreatePullRequestContextFunc == nil {
+		panic("backlogmock: PullRequestService.CreatePullRequestContextFunc is not set")
+	}
+
+	return m.CreatePullRequestContextFunc(ctx, projectId, repositoryId, values)
+}
+
+func (m *PullRequestService) UpdatePullRequest(projectId, repositoryId string, number int, values url.Values) (*backlog.PullRequest, error) {
+	if m.UpdatePullRequestFunc == nil {
+		panic("backlogmock: PullRequestService.UpdatePullRequestFunc is not set")
+	}
+
+	return m.UpdatePullRequestFunc(projectId, repositoryId, number, values)
+}
+
+func (m *PullRequestService) UpdatePullRequestContext(ctx context.Context, projectId, repositoryId string, number int, values url.Values) (*backlog.PullRequest, error) {
+	if m.UpdatePullRequestContextFunc == nil {
+		panic("backlogmock: PullRequestService.UpdatePullRequestContextFunc is not set")
+	}
+
+	return m.UpdatePullRequestContextFunc(ctx, projectId, repositoryId, number, values)
+}
+
+func (m *PullRequestService) GetPullRequestComments(projectId, repositoryId string, number int, query url.Values) ([]*backlog.PullRequestComment, error) {
+	if m.GetPullRequestCommentsFunc == nil {
+		panic("backlogmock: PullRequestService.GetPullRequestCommentsFunc is not set")
+	}
+
+	return m.GetPullRequestCommentsFunc(projectId, repositoryId, number, query)
+}
+
+func (m *PullRequestService) GetPullRequestCommentsContext(ctx context.Context, projectId, repositoryId string, number int, query url.Values) ([]*backlog.PullRequestComment, error) {
+	if m.GetPullRequestCommentsContextFunc == nil {
+		panic("backlogmock: PullRequestService.GetPullRequestCommentsContextFunc is not set")
+	}
+
+	return m.GetPullRequestCommentsContextFunc(ctx, projectId, repositoryId, number, query)
+}
+
+func (m *PullRequestService) GetPullRequestCommentsCount(projectId, repositoryId string, number int) (int, error) {
+	if m.GetPullRequestCommentsCountFunc == nil {
+		panic("backlogmock: PullRequestService.GetPullRequestCommentsCountFunc is not set")
+	}
+
+	return m.GetPullRequestCommentsCountFunc(projectId, repositoryId, number)
+}
+
+func (m *PullRequestService) GetPullRequestCommentsCountContext(ctx context.Context, projectId, repositoryId string, number int) (int, error) {
+	if m.GetPullRequestCommentsCountContextFunc == nil {
+		panic("backlogmock: PullRequestService.GetPullRequestCommentsCountContextFunc is not set")
+	}
+
+	return m.GetPullRequestCommentsCountContextFunc(ctx, projectId, repositoryId, number)
+}
+
+func (m *PullRequestService) AddPullRequestComment(projectId, repositoryId string, number int, content string, notifiedUserIds []int) (*backlog.PullRequestComment, error) {
+	if m.AddPullRequestCommentFunc == nil {
+		panic("backlogmock: PullRequestService.AddPullRequestCommentFunc is not set")
+	}
+
+	return m.AddPullRequestCommentFunc(projectId, repositoryId, number, content, notifiedUserIds)
+}
+
+func (m *PullRequestService) AddPullRequestCommentContext(ctx context.Context, projectId, repositoryId string, number int, content string, notifiedUserIds []int) (*backlog.PullRequestComment, error) {
+	if m.AddPullRequestCommentContextFunc == nil {
+		panic("backlogmock: PullRequestService.AddPullRequestCommentContextFunc is not set")
+	}
+
+	return m.AddPullRequestCommentContextFunc(ctx, projectId, repositoryId, number, content, notifiedUserIds)
+}
+
+func (m *PullRequestService) UpdatePullRequestComment(projectId, repositoryId string, number, commentId int, content string) (*backlog.PullRequestComment, error) {
+	if m.UpdatePullRequestCommentFunc == nil {
+		panic("backlogmock: PullRequestService.UpdatePullRequestCommentFunc is not set")
+	}
+
+	return m.UpdatePullRequestCommentFunc(projectId, repositoryId, number, commentId, content)
+}
+
+func (m *PullRequestService) UpdatePullRequestCommentContext(ctx context.Context, projectId, repositoryId string, number, commentId int, content string) (*backlog.PullRequestComment, error) {
+	if m.UpdatePullRequestCommentContextFunc == nil {
+		panic("backlogmock: PullRequestService.UpdatePullRequestCommentContextFunc is not set")
+	}
+
+	return m.UpdatePullRequestCommentContextFunc(ctx, projectId, repositoryId, number, commentId, content)
+}
+
+func (m *PullRequestService) GetPullRequestAttachments(projectId, repositoryId string, number int) ([]*backlog.Attachment, error) {
+	if m.GetPullRequestAttachmentsFunc == nil {
+		panic("backlogmock: PullRequestService.GetPullRequestAttachmentsFunc is not set")
+	}
+
+	return m.GetPullRequestAttachmentsFunc(projectId, repositoryId, number)
+}
+
+func (m *PullRequestService) GetPullRequestAttachmentsContext(ctx context.Context, projectId, repositoryId string, number int) ([]*backlog.Attachment, error) {
+	if m.GetPullRequestAttachmentsContextFunc == nil {
+		panic("backlogmock: PullRequestService.GetPullRequestAttachmentsContextFunc is not set")
+	}
+
+	return m.GetPullRequestAttachmentsContextFunc(ctx, projectId, repositoryId, number)
+}
+
+func (m *PullRequestService) DownloadPullRequestAttachment(projectId, repositoryId string, number, attachmentId int) ([]byte, error) {
+	if m.DownloadPullRequestAttachmentFunc == nil {
+		panic("backlogmock: PullRequestService.DownloadPullRequestAttachmentFunc is not set")
+	}
+
+	return m.DownloadPullRequestAttachmentFunc(projectId, repositoryId, number, attachmentId)
+}
+
+func (m *PullRequestService) DownloadPullRequestAttachmentContext(ctx context.Context, projectId, repositoryId string, number, attachmentId int) ([]byte, error) {
+	if m.DownloadPullRequestAttachmentContextFunc == nil {
+		panic("backlogmock: PullRequestService.DownloadPullRequestAttachmentContextFunc is not set")
+	}
+
+	return m.DownloadPullRequestAttachmentContextFunc(ctx, projectId, repositoryId, number, attachmentId)
+}
+
+func (m *PullRequestService) DeletePullRequestAttachment(projectId, repositoryId string, number, attachmentId int) (*backlog.Attachment, error) {
+	if m.DeletePullRequestAttachmentFunc == nil {
+		panic("backlogmock: PullRequestService.DeletePullRequestAttachmentFunc is not set")
+	}
+
+	return m.DeletePullRequestAttachmentFunc(projectId, repositoryId, number, attachmentId)
+}
+
+func (m *PullRequestService) DeletePullRequestAttachmentContext(ctx context.Context, projectId, repositoryId string, number, attachmentId int) (*backlog.Attachment, error) {
+	if m.DeletePullRequestAttachmentContextFunc == nil {
+		panic("backlogmock: PullRequestService.DeletePullRequestAttachmentContextFunc is not set")
+	}
+
+	return m.DeletePullRequestAttachmentContextFunc(ctx, projectId, repositoryId, number, attachmentId)
+}
+
+// WikiService is the mock of backlog.WikiService.
+type WikiService struct {
+	GetWikisFunc                      func(projectId string, query url.Values) ([]*backlog.Wiki, error)
+	GetWikisContextFunc               func(ctx context.Context, projectId string, query url.Values) ([]*backlog.Wiki, error)
+	GetWikisCountFunc                 func(projectId string) (int, error)
+	GetWikisCountContextFunc          func(ctx context.Context, projectId string) (int, error)
+	GetWikiTagsFunc                   func(projectId string) ([]*backlog.WikiTag, error)
+	GetWikiTagsContextFunc            func(ctx context.Context, projectId string) ([]*backlog.WikiTag, error)
+	GetWikiFunc                       func(wikiId int) (*backlog.Wiki, error)
+	GetWikiContextFunc                func(ctx context.Context, wikiId int) (*backlog.Wiki, error)
+	AddWikiFunc                       func(projectId int, name, content string, mailNotify bool) (*backlog.Wiki, error)
+	AddWikiContextFunc                func(ctx context.Context, projectId int, name, content string, mailNotify bool) (*backlog.Wiki, error)
+	UpdateWikiFunc                    func(wikiId int, name, content string, mailNotify bool) (*backlog.Wiki, error)
+	UpdateWikiContextFunc             func(ctx context.Context, wikiId int, name, content string, mailNotify bool) (*backlog.Wiki, error)
+	DeleteWikiFunc                    func(wikiId int, mailNotify bool) (*backlog.Wiki, error)
+	DeleteWikiContextFunc             func(ctx context.Context, wikiId int, mailNotify bool) (*backlog.Wiki, error)
+	GetWikiAttachmentsFunc            func(wikiId int) ([]*backlog.Attachment, error)
+	GetWikiAttachmentsContextFunc     func(ctx context.Context, wikiId int) ([]*backlog.Attachment, error)
+	AddWikiAttachmentsFunc            func(wikiId int, attachmentIds []int) ([]*backlog.Attachment, error)
+	AddWikiAttachmentsContextFunc     func(ctx context.Context, wikiId int, attachmentIds []int) ([]*backlog.Attachment, error)
+	DownloadWikiAttachmentFunc        func(wikiId, attachmentId int) ([]byte, error)
+	DownloadWikiAttachmentContextFunc func(ctx context.Context, wikiId, attachmentId int) ([]byte, error)
+	DeleteWikiAttachmentFunc          func(wikiId, attachmentId int) (*backlog.Attachment, error)
+	DeleteWikiAttachmentContextFunc   func(ctx context.Context, wikiId, attachmentId int) (*backlog.Attachment, error)
+	GetWikiSharedFilesFunc            func(wikiId int) ([]*backlog.SharedFile, error)
+	GetWikiSharedFilesContextFunc     func(ctx context.Context, wikiId int) ([]*backlog.SharedFile, error)
+	LinkWikiSharedFilesFunc           func(wikiId int, fileIds []int) ([]*backlog.SharedFile, error)
+	LinkWikiSharedFilesContextFunc    func(ctx context.Context, wikiId int, fileIds []int) ([]*backlog.SharedFile, error)
+	UnlinkWikiSharedFileFunc          func(wikiId, sharedFileId int) (*backlog.SharedFile, error)
+	UnlinkWikiSharedFileContextFunc   func(ctx context.Context, wikiId, sharedFileId int) (*backlog.SharedFile, error)
+	GetWikiHistoryFunc                func(wikiId int, query url.Values) ([]*backlog.WikiHistory, error)
+	GetWikiHistoryContextFunc         func(ctx context.Context, wikiId int, query url.Values) ([]*backlog.WikiHistory, error)
+	GetWikiStarsFunc                  func(wikiId int) ([]*backlog.Star, error)
+	GetWikiStarsContextFunc           func(ctx context.Context, wikiId int) ([]*backlog.Star, error)
+}
+
+func (m *WikiService) GetWikis(projectId string, query url.Values) ([]*backlog.Wiki, error) {
+	if m.GetWikisFunc == nil {
+		panic("backlogmock: WikiService.GetWikisFunc is not set")
+	}
+
+	return m.GetWikisFunc(projectId, query)
+}
+
+func (m *WikiService) GetWikisContext(ctx context.Context, projectId string, query url.Values) ([]*backlog.Wiki, error) {
+	if m.GetWikisContextFunc == nil {
+		panic("backlogmock: WikiService.GetWikisContextFunc is not set")
+	}
+
+	return m.GetWikisContextFunc(ctx, projectId, query)
+}
+
+func (m *WikiService) GetWikisCount(projectId string) (int, error) {
+	if m.GetWikisCountFunc == nil {
+		panic("backlogmock: WikiService.GetWikisCountFunc is not set")
+	}
+
+	return m.GetWikisCountFunc(projectId)
+}
+
+func (m *WikiService) GetWikisCountContext(ctx context.Context, projectId string) (int, error) {
+	if m.GetWikisCountContextFunc == nil {
+		panic("backlogmock: WikiService.GetWikisCountContextFunc is not set")
+	}
+
+	return m.GetWikisCountContextFunc(ctx, projectId)
+}
+
+func (m *WikiService) GetWikiTags(projectId string) ([]*backlog.WikiTag, error) {
+	if m.GetWikiTagsFunc == nil {
+		panic("backlogmock: WikiService.GetWikiTagsFunc is not set")
+	}
+
+	return m.GetWikiTagsFunc(projectId)
+}
+
+func (m *WikiService) GetWikiTagsContext(ctx context.Context, projectId string) ([]*backlog.WikiTag, error) {
+	if m.GetWikiTagsContextFunc == nil {
+		panic("backlogmock: WikiService.GetWikiTagsContextFunc is not set")
+	}
+
+	return m.GetWikiTagsContextFunc(ctx, projectId)
+}
+
+func (m *WikiService) GetWiki(wikiId int) (*backlog.Wiki, error) {
+	if m.GetWikiFunc == nil {
+		panic("backlogmock: WikiService.GetWikiFunc is not set")
+	}
+
+	return m.GetWikiFunc(wikiId)
+}
+
+func (m *WikiService) GetWikiContext(ctx context.Context, wikiId int) (*backlog.Wiki, error) {
+	if m.GetWikiContextFunc == nil {
+		panic("backlogmock: WikiService.GetWikiContextFunc is not set")
+	}
+
+	return m.GetWikiContextFunc(ctx, wikiId)
+}
+
+func (m *WikiService) AddWiki(projectId int, name, content string, mailNotify bool) (*backlog.Wiki, error) {
+	if m.AddWikiFunc == nil {
+		panic("backlogmock: WikiService.AddWikiFunc is not set")
+	}
+
+	return m.AddWikiFunc(projectId, name, content, mailNotify)
+}
+
+func (m *WikiService) AddWikiContext(ctx context.Context, projectId int, name, content string, mailNotify bool) (*backlog.Wiki, error) {
+	if m.AddWikiContextFunc == nil {
+		panic("backlogmock: WikiService.AddWikiContextFunc is not set")
+	}
+
+	return m.AddWikiContextFunc(ctx, projectId, name, content, mailNotify)
+}
+
+func (m *WikiService) UpdateWiki(wikiId int, name, content string, mailNotify bool) (*backlog.Wiki, error) {
+	if m.UpdateWikiFunc == nil {
+		panic("backlogmock: WikiService.UpdateWikiFunc is not set")
+	}
+
+	return m.UpdateWikiFunc(wikiId, name, content, mailNotify)
+}
+
+func (m *WikiService) UpdateWikiContext(ctx context.Context, wikiId int, name, content string, mailNotify bool) (*backlog.Wiki, error) {
+	if m.UpdateWikiContextFunc == nil {
+		panic("backlogmock: WikiService.UpdateWikiContextFunc is not set")
+	}
+
+	return m.UpdateWikiContextFunc(ctx, wikiId, name, content, mailNotify)
+}
+
+func (m *WikiService) DeleteWiki(wikiId int, mailNotify bool) (*backlog.Wiki, error) {
+	if m.DeleteWikiFunc == nil {
+		panic("backlogmock: WikiService.DeleteWikiFunc is not set")
+	}
+
+	return m.DeleteWikiFunc(wikiId, mailNotify)
+}
+
+func (m *WikiService) DeleteWikiContext(ctx context.Context, wikiId int, mailNotify bool) (*backlog.Wiki, error) {
+	if m.DeleteWikiContextFunc == nil {
+		panic("backlogmock: WikiService.DeleteWikiContextFunc is not set")
+	}
+
+	return m.DeleteWikiContextFunc(ctx, wikiId, mailNotify)
+}
+
+func (m *WikiService) GetWikiAttachments(wikiId int) ([]*backlog.Attachment, error) {
+	if m.GetWikiAttachmentsFunc == nil {
+		panic("backlogmock: WikiService.GetWikiAttachmentsFunc is not set")
+	}
+
+	return m.GetWikiAttachmentsFunc(wikiId)
+}
+
+func (m *WikiService) GetWikiAttachmentsContext(ctx context.Context, wikiId int) ([]*backlog.Attachment, error) {
+	if m.GetWikiAttachmentsContextFunc == nil {
+		panic("backlogmock: WikiService.GetWikiAttachmentsContextFunc is not set")
+	}
+
+	return m.GetWikiAttachmentsContextFunc(ctx, wikiId)
+}
+
+func (m *WikiService) AddWikiAttachments(wikiId int, attachmentIds []int) ([]*backlog.Attachment, error) {
+	if m.AddWikiAttachmentsFunc == nil {
+		panic("backlogmock: WikiService.AddWikiAttachmentsFunc is not set")
+	}
+
+	return m.AddWikiAttachmentsFunc(wikiId, attachmentIds)
+}
+
+func (m *WikiService) AddWikiAttachmentsContext(ctx context.Context, wikiId int, attachmentIds []int) ([]*backlog.Attachment, error) {
+	if m.AddWikiAttachmentsContextFunc == nil {
+		panic("backlogmock: WikiService.AddWikiAttachmentsContextFunc is not set")
+	}
+
+	return m.AddWikiAttachmentsContextFunc(ctx, wikiId, attachmentIds)
+}
+
+func (m *WikiService) DownloadWikiAttachment(wikiId, attachmentId int) ([]byte, error) {
+	if m.DownloadWikiAttachmentFunc == nil {
+		panic("backlogmock: WikiService.DownloadWikiAttachmentFunc is not set")
+	}
+
+	return m.DownloadWikiAttachmentFunc(wikiId, attachmentId)
+}
+
+func (m *WikiService) DownloadWikiAttachmentContext(ctx context.Context, wikiId, attachmentId int) ([]byte, error) {
+	if m.DownloadWikiAttachmentContextFunc == nil {
+		panic("backlogmock: WikiService.DownloadWikiAttachmentContextFunc is not set")
+	}
+
+	return m.DownloadWikiAttachmentContextFunc(ctx, wikiId, attachmentId)
+}
+
+func (m *WikiService) DeleteWikiAttachment(wikiId, attachmentId int) (*backlog.Attachment, error) {
+	if m.DeleteWikiAttachmentFunc == nil {
+		panic("backlogmock: WikiService.DeleteWikiAttachmentFunc is not set")
+	}
+
+	return m.DeleteWikiAttachmentFunc(wikiId, attachmentId)
+}
+
+func (m *WikiService) DeleteWikiAttachmentContext(ctx context.Context, wikiId, attachmentId int) (*backlog.Attachment, error) {
+	if m.DeleteWikiAttachmentContextFunc == nil {
+		panic("backlogmock: WikiService.DeleteWikiAttachmentContextFunc is not set")
+	}
+
+	return m.DeleteWikiAttachmentContextFunc(ctx, wikiId, attachmentId)
+}
+
+func (m *WikiService) GetWikiSharedFiles(wikiId int) ([]*backlog.SharedFile, error) {
+	if m.GetWikiSharedFilesFunc == nil {
+		panic("backlogmock: WikiService.GetWikiSharedFilesFunc is not set")
+	}
+
+	return m.GetWikiSharedFilesFunc(wikiId)
+}
+
+func (m *WikiService) GetWikiSharedFilesContext(ctx context.Context, wikiId int) ([]*backlog.SharedFile, error) {
+	if m.GetWikiSharedFilesContextFunc == nil {
+		panic("backlogmock: WikiService.GetWikiSharedFilesContextFunc is not set")
+	}
+
+	return m.GetWikiSharedFilesContextFunc(ctx, wikiId)
+}
+
+func (m *WikiService) LinkWikiSharedFiles(wikiId int, fileIds []int) ([]*backlog.SharedFile, error) {
+	if m.LinkWikiSharedFilesFunc == nil {
+		panic("backlogmock: WikiService.LinkWikiSharedFilesFunc is not set")
+	}
+
+	return m.LinkWikiSharedFilesFunc(wikiId, fileIds)
+}
+
+func (m *WikiService) LinkWikiSharedFilesContext(ctx context.Context, wikiId int, fileIds []int) ([]*backlog.SharedFile, error) {
+	if m.LinkWikiSharedFilesContextFunc == nil {
+		panic("backlogmock: WikiService.LinkWikiSharedFilesContextFunc is not set")
+	}
+
+	return m.LinkWikiSharedFilesContextFunc(ctx, wikiId, fileIds)
+}
+
+func (m *WikiService) UnlinkWikiSharedFile(wikiId, sharedFileId int) (*backlog.SharedFile, error) {
+	if m.UnlinkWikiSharedFileFunc == nil {
+		panic("backlogmock: WikiService.UnlinkWikiSharedFileFunc is not set")
+	}
+
+	return m.UnlinkWikiSharedFileFunc(wikiId, sharedFileId)
+}
+
+func (m *WikiService) UnlinkWikiSharedFileContext(ctx context.Context, wikiId, sharedFileId int) (*backlog.SharedFile, error) {
+	if m.UnlinkWikiSharedFileContextFunc == nil {
+		panic("backlogmock: WikiService.UnlinkWikiSharedFileContextFunc is not set")
+	}
+
+	return m.UnlinkWikiSharedFileContextFunc(ctx, wikiId, sharedFileId)
+}
+
+func (m *WikiService) GetWikiHistory(wikiId int, query url.Values) ([]*backlog.WikiHistory, error) {
+	if m.GetWikiHistoryFunc == nil {
+		panic("backlogmock: WikiService.GetWikiHistoryFunc is not set")
+	}
+
+	return m.GetWikiHistoryFunc(wikiId, query)
+}
+
+func (m *WikiService) GetWikiHistoryContext(ctx context.Context, wikiId int, query url.Values) ([]*backlog.WikiHistory, error) {
+	if m.GetWikiHistoryContextFunc == nil {
+		panic("backlogmock: WikiService.GetWikiHistoryContextFunc is not set")
+	}
+
+	return m.GetWikiHistoryContextFunc(ctx, wikiId, query)
+}
+
+func (m *WikiService) GetWikiStars(wikiId int) ([]*backlog.Star, error) {
+	if m.GetWikiStarsFunc == nil {
+		panic("backlogmock: WikiService.GetWikiStarsFunc is not set")
+	}
+
+	return m.GetWikiStarsFunc(wikiId)
+}
+
+func (m *WikiService) GetWikiStarsContext(ctx context.Context, wikiId int) ([]*backlog.Star, error) {
+	if m.GetWikiStarsContextFunc == nil {
+		panic("backlogmock: WikiService.GetWikiStarsContextFunc is not set")
+	}
+
+	return m.GetWikiStarsContextFunc(ctx, wikiId)
+}
+
+// StarService is the mock of backlog.StarService.
+type StarService struct {
+	AddStarFunc                  func(target backlog.StarTarget, id int) error
+	AddStarContextFunc           func(ctx context.Context, target backlog.StarTarget, id int) error
+	RemoveStarFunc               func(starId int) error
+	RemoveStarContextFunc        func(ctx context.Context, starId int) error
+	GetUserStarsFunc             func(userId int, query url.Values) ([]*backlog.Star, error)
+	GetUserStarsContextFunc      func(ctx context.Context, userId int, query url.Values) ([]*backlog.Star, error)
+	GetUserStarsCountFunc        func(userId int, since, until time.Time) (int, error)
+	GetUserStarsCountContextFunc func(ctx context.Context, userId int, since, until time.Time) (int, error)
+}
+
+func (m *StarService) AddStar(target backlog.StarTarget, id int) error {
+	if m.AddStarFunc == nil {
+		panic("backlogmock: StarService.AddStarFunc is not set")
+	}
+
+	return m.AddStarFunc(target, id)
+}
+
+func (m *StarService) AddStarContext(ctx context.Context, target backlog.StarTarget, id int) error {
+	if m.AddStarContextFunc == nil {
+		panic("backlogmock: StarService.AddStarContextFunc is not set")
+	}
+
+	return m.AddStarContextFunc(ctx, target, id)
+}
+
+func (m *StarService) RemoveStar(starId int) error {
+	if m.RemoveStarFunc == nil {
+		panic("backlogmock: StarService.RemoveStarFunc is not set")
+	}
+
+	return m.RemoveStarFunc(starId)
+}
+
+func (m *StarService) RemoveStarContext(ctx context.Context, starId int) error {
+	if m.RemoveStarContextFunc == nil {
+		panic("backlogmock: StarService.RemoveStarContextFunc is not set")
+	}
+
+	return m.RemoveStarContextFunc(ctx, starId)
+}
+
+func (m *StarService) GetUserStars(userId int, query url.Values) ([]*backlog.Star, error) {
+	if m.GetUserStarsFunc == nil {
+		panic("backlogmock: StarService.GetUserStarsFunc is not set")
+	}
+
+	return m.GetUserStarsFunc(userId, query)
+}
+
+func (m *StarService) GetUserStarsContext(ctx context.Context, userId int, query url.Values) ([]*backlog.Star, error) {
+	if m.GetUserStarsContextFunc == nil {
+		panic("backlogmock: StarService.GetUserStarsContextFunc is not set")
+	}
+
+	return m.GetUserStarsContextFunc(ctx, userId, query)
+}
+
+func (m *StarService) GetUserStarsCount(userId int, since, until time.Time) (int, error) {
+	if m.GetUserStarsCountFunc == nil {
+		panic("backlogmock: StarService.GetUserStarsCountFunc is not set")
+	}
+
+	return m.GetUserStarsCountFunc(userId, since, until)
+}
+
+func (m *StarService) GetUserStarsCountContext(ctx context.Context, userId int, since, until time.Time) (int, error) {
+	if m.GetUserStarsCountContextFunc == nil {
+		panic("backlogmock: StarService.GetUserStarsCountContextFunc is not set")
+	}
+
+	return m.GetUserStarsCountContextFunc(ctx, userId, since, until)
+}
+
+// NotificationService is the mock of backlog.NotificationService.
+type NotificationService struct {
+	GetNotificationsFunc              func(query url.Values) ([]*backlog.Notification, error)
+	GetNotificationsContextFunc       func(ctx context.Context, query url.Values) ([]*backlog.Notification, error)
+	GetNotificationsCountFunc         func(query url.Values) (int, error)
+	GetNotificationsCountContextFunc  func(ctx context.Context, query url.Values) (int, error)
+	ResetNotificationCountFunc        func() (int, error)
+	ResetNotificationCountContextFunc func(ctx context.Context) (int, error)
+	MarkNotificationReadFunc          func(notificationId int) error
+	MarkNotificationReadContextFunc   func(ctx context.Context, notificationId int) error
+}
+
+func (m *NotificationService) GetNotifications(query url.Values) ([]*backlog.Notification, error) {
+	if m.GetNotificationsFunc == nil {
+		panic("backlogmock: NotificationService.GetNotificationsFunc is not set")
+	}
+
+	return m.GetNotificationsFunc(query)
+}
+
+func (m *NotificationService) GetNotificationsContext(ctx context.Context, query url.Values) ([]*backlog.Notification, error) {
+	if m.GetNotificationsContextFunc == nil {
+		panic("backlogmock: NotificationService.GetNotificationsContextFunc is not set")
+	}
+
+	return m.GetNotificationsContextFunc(ctx, query)
+}
+
+func (m *NotificationService) GetNotificationsCount(query url.Values) (int, error) {
+	if m.GetNotificationsCountFunc == nil {
+		panic("backlogmock: NotificationService.GetNotificationsCountFunc is not set")
+	}
+
+	return m.GetNotificationsCountFunc(query)
+}
+
+func (m *NotificationService) GetNotificationsCountContext(ctx context.Context, query url.Values) (int, error) {
+	if m.GetNotificationsCountContextFunc == nil {
+		panic("backlogmock: NotificationService.GetNotificationsCountContextFunc is not set")
+	}
+
+	return m.GetNotificationsCountContextFunc(ctx, query)
+}
+
+func (m *NotificationService) ResetNotificationCount() (int, error) {
+	if m.ResetNotificationCountFunc == nil {
+		panic("backlogmock: NotificationService.ResetNotificationCountFunc is not set")
+	}
+
+	return m.ResetNotificationCountFunc()
+}
+
+func (m *NotificationService) ResetNotificationCountContext(ctx context.Context) (int, error) {
+	if m.ResetNotificationCountContextFunc == nil {
+		panic("backlogmock: NotificationService.ResetNotificationCountContextFunc is not set")
+	}
+
+	return m.ResetNotificationCountContextFunc(ctx)
+}
+
+func (m *NotificationService) MarkNotificationRead(notificationId int) error {
+	if m.MarkNotificationReadFunc == nil {
+		panic("backlogmock: NotificationService.MarkNotificationReadFunc is not set")
+	}
+
+	return m.MarkNotificationReadFunc(notificationId)
+}
+
+func (m *NotificationService) MarkNotificationReadContext(ctx context.Context, notificationId int) error {
+	if m.MarkNotificationReadContextFunc == nil {
+		panic("backlogmock: NotificationService.MarkNotificationReadContextFunc is not set")
+	}
+
+	return m.MarkNotificationReadContextFunc(ctx, notificationId)
+}
+
+// WatchingService is the mock of backlog.WatchingService.
+type WatchingService struct {
+	GetWatchingsFunc             func(userId int, query url.Values) ([]*backlog.Watching, error)
+	GetWatchingsContextFunc      func(ctx context.Context, userId int, query url.Values) ([]*backlog.Watching, error)
+	GetWatchingsCountFunc        func(userId int, query url.Values) (int, error)
+	GetWatchingsCountContextFunc func(ctx context.Context, userId int, query url.Values) (int, error)
+	GetWatchingFunc              func(watchingId int) (*backlog.Watching, error)
+	GetWatchingContextFunc       func(ctx context.Context, watchingId int) (*backlog.Watching, error)
+	AddWatchingFunc              func(issueId, note string) (*backlog.Watching, error)
+	AddWatchingContextFunc       func(ctx context.Context, issueId, note string) (*backlog.Watching, error)
+	UpdateWatchingFunc           func(watchingId int, note string) (*backlog.Watching, error)
+	UpdateWatchingContextFunc    func(ctx context.Context, watchingId int, note string) (*backlog.Watching, error)
+	DeleteWatchingFunc           func(watchingId int) (*backlog.Watching, error)
+	DeleteWatchingContextFunc    func(ctx context.Context, watchingId int) (*backlog.Watching, error)
+	MarkWatchingReadFunc         func(watchingId int) error
+	MarkWatchingReadContextFunc  func(ctx context.Context, watchingId int) error
+}
+
+func (m *WatchingService) GetWatchings(userId int, query url.Values) ([]*backlog.Watching, error) {
+	if m.GetWatchingsFunc == nil {
+		panic("backlogmock: WatchingService.GetWatchingsFunc is not set")
+	}
+
+	return m.GetWatchingsFunc(userId, query)
+}
+
+func (m *WatchingService) GetWatchingsContext(ctx context.Context, userId int, query url.Values) ([]*backlog.Watching, error) {
+	if m.GetWatchingsContextFunc == nil {
+		panic("backlogmock: WatchingService.GetWatchingsContextFunc is not set")
+	}
+
+	return m.GetWatchingsContextFunc(ctx, userId, query)
+}
+
+func (m *WatchingService) GetWatchingsCount(userId int, query url.Values) (int, error) {
+	if m.GetWatchingsCountFunc == nil {
+		panic("backlogmock: WatchingService.GetWatchingsCountFunc is not set")
+	}
+
+	return m.GetWatchingsCountFunc(userId, query)
+}
+
+func (m *WatchingService) GetWatchingsCountContext(ctx context.Context, userId int, query url.Values) (int, error) {
+	if m.GetWatchingsCountContextFunc == nil {
+		panic("backlogmock: WatchingService.GetWatchingsCountContextFunc is not set")
+	}
+
+	return m.GetWatchingsCountContextFunc(ctx, userId, query)
+}
+
+func (m *WatchingService) GetWatching(watchingId int) (*backlog.Watching, error) {
+	if m.GetWatchingFunc == nil {
+		panic("backlogmock: WatchingService.GetWatchingFunc is not set")
+	}
+
+	return m.GetWatchingFunc(watchingId)
+}
+
+func (m *WatchingService) GetWatchingContext(ctx context.Context, watchingId int) (*backlog.Watching, error) {
+	if m.GetWatchingContextFunc == nil {
+		panic("backlogmock: WatchingService.GetWatchingContextFunc is not set")
+	}
+
+	return m.GetWatchingContextFunc(ctx, watchingId)
+}
+
+func (m *WatchingService) AddWatching(issueId, note string) (*backlog.Watching, error) {
+	if m.AddWatchingFunc == nil {
+		panic("backlogmock: WatchingService.AddWatchingFunc is not set")
+	}
+
+	return m.AddWatchingFunc(issueId, note)
+}
+
+func (m *WatchingService) AddWatchingContext(ctx context.Context, issueId, note string) (*backlog.Watching, error) {
+	if m.AddWatchingContextFunc == nil {
+		panic("backlogmock: WatchingService.AddWatchingContextFunc is not set")
+	}
+
+	return m.AddWatchingContextFunc(ctx, issueId, note)
+}
+
+func (m *WatchingService) UpdateWatching(watchingId int, note string) (*backlog.Watching, error) {
+	if m.UpdateWatchingFunc == nil {
+		panic("backlogmock: WatchingService.UpdateWatchingFunc is not set")
+	}
+
+	return m.UpdateWatchingFunc(watchingId, note)
+}
+
+func (m *WatchingService) UpdateWatchingContext(ctx context.Context, watchingId int, note string) (*backlog.Watching, error) {
+	if m.UpdateWatchingContextFunc == nil {
+		panic("backlogmock: WatchingService.UpdateWatchingContextFunc is not set")
+	}
+
+	return m.UpdateWatchingContextFunc(ctx, watchingId, note)
+}
+
+func (m *WatchingService) DeleteWatching(watchingId int) (*backlog.Watching, error) {
+	if m.DeleteWatchingFunc == nil {
+		panic("backlogmock: WatchingService.DeleteWatchingFunc is not set")
+	}
+
+	return m.DeleteWatchingFunc(watchingId)
+}
+
+func (m *WatchingService) DeleteWatchingContext(ctx context.Context, watchingId int) (*backlog.Watching, error) {
+	if m.DeleteWatchingContextFunc == nil {
+		panic("backlogmock: WatchingService.DeleteWatchingContextFunc is not set")
+	}
+
+	return m.DeleteWatchingContextFunc(ctx, watchingId)
+}
+
+func (m *WatchingService) MarkWatchingRead(watchingId int) error {
+	if m.MarkWatchingReadFunc == nil {
+		panic("backlogmock: WatchingService.MarkWatchingReadFunc is not set")
+	}
+
+	return m.MarkWatchingReadFunc(watchingId)
+}
+
+func (m *WatchingService) MarkWatchingReadContext(ctx context.Context, watchingId int) error {
+	if m.MarkWatchingReadContextFunc == nil {
+		panic("backlogmock: WatchingService.MarkWatchingReadContextFunc is not set")
+	}
+
+	return m.MarkWatchingReadContextFunc(ctx, watchingId)
+}
+
+// WebhookService is the mock of backlog.WebhookService.
+type WebhookService struct {
+	GetWebhooksFunc          func(projectId string) ([]*backlog.Webhook, error)
+	GetWebhooksContextFunc   func(ctx context.Context, projectId string) ([]*backlog.Webhook, error)
+	GetWebhookFunc           func(projectId string, webhookId int) (*backlog.Webhook, error)
+	GetWebhookContextFunc    func(ctx context.Context, projectId string, webhookId int) (*backlog.Webhook, error)
+	AddWebhookFunc           func(projectId string, webhook *backlog.Webhook) (*backlog.Webhook, error)
+	AddWebhookContextFunc    func(ctx context.Context, projectId string, webhook *backlog.Webhook) (*backlog.Webhook, error)
+	UpdateWebhookFunc        func(projectId string, webhook *backlog.Webhook) (*backlog.Webhook, error)
+	UpdateWebhookContextFunc func(ctx context.Context, projectId string, webhook *backlog.Webhook) (*backlog.Webhook, error)
+	DeleteWebhookFunc        func(projectId string, webhookId int) (*backlog.Webhook, error)
+	DeleteWebhookContextFunc func(ctx context.Context, projectId string, webhookId int) (*backlog.Webhook, error)
+}
+
+func (m *WebhookService) GetWebhooks(projectId string) ([]*backlog.Webhook, error) {
+	if m.GetWebhooksFunc == nil {
+		panic("backlogmock: WebhookService.GetWebhooksFunc is not set")
+	}
+
+	return m.GetWebhooksFunc(projectId)
+}
+
+func (m *WebhookService) GetWebhooksContext(ctx context.Context, projectId string) ([]*backlog.Webhook, error) {
+	if m.GetWebhooksContextFunc == nil {
+		panic("backlogmock: WebhookService.GetWebhooksContextFunc is not set")
+	}
+
+	return m.GetWebhooksContextFunc(ctx, projectId)
+}
+
+func (m *WebhookService) GetWebhook(projectId string, webhookId int) (*backlog.Webhook, error) {
+	if m.GetWebhookFunc == nil {
+		panic("backlogmock: WebhookService.GetWebhookFunc is not set")
+	}
+
+	return m.GetWebhookFunc(projectId, webhookId)
+}
+
+func (m *WebhookService) GetWebhookContext(ctx context.Context, projectId string, webhookId int) (*backlog.Webhook, error) {
+	if m.GetWebhookContextFunc == nil {
+		panic("backlogmock: WebhookService.GetWebhookContextFunc is not set")
+	}
+
+	return m.GetWebhookContextFunc(ctx, projectId, webhookId)
+}
+
+func (m *WebhookService) AddWebhook(projectId string, webhook *backlog.Webhook) (*backlog.Webhook, error) {
+	if m.AddWebhookFunc == nil {
+		panic("backlogmock: WebhookService.AddWebhookFunc is not set")
+	}
+
+	return m.AddWebhookFunc(projectId, webhook)
+}
+
+func (m *WebhookService) AddWebhookContext(ctx context.Context, projectId string, webhook *backlog.Webhook) (*backlog.Webhook, error) {
+	if m.AddWebhookContextFunc == nil {
+		panic("backlogmock: WebhookService.AddWebhookContextFunc is not set")
+	}
+
+	return m.AddWebhookContextFunc(ctx, projectId, webhook)
+}
+
+func (m *WebhookService) UpdateWebhook(projectId string, webhook *backlog.Webhook) (*backlog.Webhook, error) {
+	if m.UpdateWebhookFunc == nil {
+		panic("backlogmock: WebhookService.UpdateWebhookFunc is not set")
+	}
+
+	return m.UpdateWebhookFunc(projectId, webhook)
+}
+
+func (m *WebhookService) UpdateWebhookContext(ctx context.Context, projectId string, webhook *backlog.Webhook) (*backlog.Webhook, error) {
+	if m.UpdateWebhookContextFunc == nil {
+		panic("backlogmock: WebhookService.UpdateWebhookContextFunc is not set")
+	}
+
+	return m.UpdateWebhookContextFunc(ctx, projectId, webhook)
+}
+
+func (m *WebhookService) DeleteWebhook(projectId string, webhookId int) (*backlog.Webhook, error) {
+	if m.DeleteWebhookFunc == nil {
+		panic("backlogmock: WebhookService.DeleteWebhookFunc is not set")
+	}
+
+	return m.DeleteWebhookFunc(projectId, webhookId)
+}
+
+func (m *WebhookService) DeleteWebhookContext(ctx context.Context, projectId string, webhookId int) (*backlog.Webhook, error) {
+	if m.DeleteWebhookContextFunc == nil {
+		panic("backlogmock: WebhookService.DeleteWebhookContextFunc is not set")
+	}
+
+	return m.DeleteWebhookContextFunc(ctx, projectId, webhookId)
+}
+
+// API is the mock of backlog.API, which consists of the mocks of the services.
+type API struct {
+	ProjectService
+	IssueService
+	CommentService
+	UserService
+	SpaceService
+	RepositoryService
+	PullRequestService
+	WikiService
+	StarService
+	NotificationService
+	WatchingService
+	WebhookService
+}
+
+var (
+	_ backlog.ProjectService      = (*ProjectService)(nil)
+	_ backlog.IssueService        = (*IssueService)(nil)
+	_ backlog.CommentService      = (*CommentService)(nil)
+	_ backlog.UserService         = (*UserService)(nil)
+	_ backlog.SpaceService        = (*SpaceService)(nil)
+	_ backlog.RepositoryService   = (*RepositoryService)(nil)
+	_ backlog.PullRequestService  = (*PullRequestService)(nil)
+	_ backlog.WikiService         = (*WikiService)(nil)
+	_ backlog.StarService         = (*StarService)(nil)
+	_ backlog.NotificationService = (*NotificationService)(nil)
+	_ backlog.WatchingService     = (*WatchingService)(nil)
+	_ backlog.WebhookService      = (*WebhookService)(nil)
+	_ backlog.API                 = (*API)(nil)
+)
@@ -0,0 +1,44 @@
+package backlogmock
+
+import (
+	"testing"
+
+	backlog "github.com/moutend/go-backlog"
+)
+
+// summary is the code under test, which accepts the narrowest service it needs.
+func summary(issues backlog.IssueService, issueKey string) (string, error) {
+	issue, err := issues.GetIssue(issueKey)
+	if err != nil {
+		return "", err
+	}
+
+	return issue.IssueKey + " " + issue.Summary, nil
+}
+
+func TestIssueService(t *testing.T) {
+	issues := &IssueService{
+		GetIssueFunc: func(issueId string) (*backlog.Issue, error) {
+			return &backlog.Issue{IssueKey: issueId, Summary: "Fix login"}, nil
+		},
+	}
+
+	actual, err := summary(issues, "SAMPLE-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "SAMPLE-1 Fix login"; actual != expected {
+		t.Fatalf("expected: %s actual: %s", expected, actual)
+	}
+}
+
+func TestAPIPanicsWithoutFunc(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+
+	var api backlog.API = &API{}
+	api.GetStatuses()
+}
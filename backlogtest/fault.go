@@ -0,0 +1,217 @@
+package backlogtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	backlog "github.com/moutend/go-backlog"
+)
+
+// Fault represents the failure injected into the responses of the Server.
+type Fault struct {
+	// Method selects the requests by the method. The empty method matches all requests.
+	Method string
+	// Path selects the requests by the pattern of path.Match relative to /api/v2, such as "issues/*". The empty pattern matches all requests.
+	Path string
+	// Times is the number of the requests the fault is injected into. The fault is injected into every request if Times is 0.
+	Times int
+
+	// Latency delays the response.
+	Latency time.Duration
+	// StatusCode, Header and Body replace the response if StatusCode is not 0.
+	StatusCode int
+	Header     http.Header
+	Body       string
+	// Truncate cuts the body of the response in half.
+	Truncate bool
+}
+
+// Latency returns the fault which delays every response by d.
+func Latency(d time.Duration) Fault {
+	return Fault{Latency: d}
+}
+
+// RateLimited returns the fault which responds with 429 Too Many Requests and the rate limit headers reset at reset, as Backlog does when the rate limit is exceeded.
+func RateLimited(times int, reset time.Time) Fault {
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "150")
+	header.Set("X-RateLimit-Remaining", "0")
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+
+	return Fault{
+		Times:      times,
+		StatusCode: http.StatusTooManyRequests,
+		Header:     header,
+		Body:       errorsBody(1, "Too many requests."),
+	}
+}
+
+// ServerErrors returns the fault which responds with 503 Service Unavailable times in a row.
+func ServerErrors(times int) Fault {
+	return Fault{
+		Times:      times,
+		StatusCode: http.StatusServiceUnavailable,
+		Body:       errorsBody(1, "Service unavailable."),
+	}
+}
+
+// TruncatedBody returns the fault which cuts the body of the response in half. The full length is declared, so the client fails to read the body with unexpected EOF.
+func TruncatedBody(times int) Fault {
+	return Fault{
+		Times:    times,
+		Truncate: true,
+	}
+}
+
+// MalformedJSON returns the fault which responds with 200 OK and the body which is not valid JSON.
+func MalformedJSON(times int) Fault {
+	return Fault{
+		Times:      times,
+		StatusCode: http.StatusOK,
+		Body:       `{"id":1,`,
+	}
+}
+
+// BrokenErrorResponse returns the fault which responds with 500 Internal Server Error and the errors without any entry.
+func BrokenErrorResponse(times int) Fault {
+	return Fault{
+		Times:      times,
+		StatusCode: http.StatusInternalServerError,
+		Body:       `{"errors":[]}`,
+	}
+}
+
+// Inject adds the faults. The faults are applied in the order of injection. Only the first fault which replaces the response is applied and consumed, so the faults which replace the response are served one after another.
+func (s *Server) Inject(faults ...Fault) {
+	s.faultsMu.Lock()
+	defer s.faultsMu.Unlock()
+
+	for _, fault := range faults {
+		fault := fault
+		s.faults = append(s.faults, &fault)
+	}
+}
+
+// ClearFaults removes all faults.
+func (s *Server) ClearFaults() {
+	s.faultsMu.Lock()
+	defer s.faultsMu.Unlock()
+
+	s.faults = nil
+}
+
+// matchFaults returns the faults applied to the request and consumes them. Only the first fault which replaces the response is applied, and the others are left for the following requests.
+func (s *Server) matchFaults(r *http.Request) []Fault {
+	s.faultsMu.Lock()
+	defer s.faultsMu.Unlock()
+
+	relative := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v2"), "/")
+	matched := []Fault{}
+	remaining := s.faults[:0]
+	replaced := false
+
+	for _, fault := range s.faults {
+		ok := fault.Method == "" || fault.Method == r.Method
+
+		if ok && fault.Path != "" {
+			ok, _ = path.Match(fault.Path, relative)
+		}
+
+		replaces := fault.StatusCode != 0 || fault.Truncate
+
+		if ok && !(replaces && replaced) {
+			matched = append(matched, *fault)
+			replaced = replaced || replaces
+
+			if fault.Times > 0 {
+				fault.Times--
+				if fault.Times == 0 {
+					continue
+				}
+			}
+		}
+
+		remaining = append(remaining, fault)
+	}
+
+	s.faults = remaining
+
+	return matched
+}
+
+// serveFaults writes the response with the faults. It returns false if the request should be served as usual.
+func (s *Server) serveFaults(w http.ResponseWriter, r *http.Request) bool {
+	faults := s.matchFaults(r)
+	truncate := false
+
+	for _, fault := range faults {
+		if !wait(r, fault.Latency) {
+			// The client has gone away, so nothing is written.
+			return true
+		}
+		truncate = truncate || fault.Truncate
+	}
+	for _, fault := range faults {
+		if fault.StatusCode == 0 {
+			continue
+		}
+		for key, values := range fault.Header {
+			w.Header()[key] = values
+		}
+
+		w.Header().Set("Content-Type", "application/json;charset=utf-8")
+		w.WriteHeader(fault.StatusCode)
+		w.Write([]byte(fault.Body))
+
+		return true
+	}
+	if !truncate {
+		return false
+	}
+
+	recorder := httptest.NewRecorder()
+	s.serve(recorder, r)
+
+	for key, values := range recorder.Header() {
+		w.Header()[key] = values
+	}
+
+	body := recorder.Body.Bytes()
+
+	// The full length is declared, so the connection is closed before the client reads the whole body.
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(recorder.Code)
+	w.Write(body[:len(body)/2])
+
+	return true
+}
+
+// wait sleeps for d unless the request is canceled. It returns false if the request is canceled.
+func wait(r *http.Request, d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-r.Context().Done():
+		return false
+	}
+}
+
+func errorsBody(code int, message string) string {
+	body, _ := json.Marshal(backlog.Errors{
+		Errors: []backlog.Error{{Message: message, Code: code}},
+	})
+
+	return string(body)
+}
@@ -0,0 +1,134 @@
+package backlogtest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	backlog "github.com/moutend/go-backlog"
+)
+
+func TestFaultRetry(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.Inject(
+		ServerErrors(2),
+		RateLimited(1, time.Now().Add(-time.Second)),
+	)
+
+	var statusCodes []string
+
+	client := server.Client()
+	client.Use(backlog.RetryMiddleware(3, time.Millisecond))
+	client.Use(func(next backlog.Doer) backlog.Doer {
+		return backlog.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			res, err := next.Do(req)
+			if err == nil {
+				statusCodes = append(statusCodes, strconv.Itoa(res.StatusCode))
+			}
+			return res, err
+		})
+	})
+
+	statuses, err := client.GetStatuses()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 4 {
+		t.Fatalf("unexpected statuses: %+v", statuses)
+	}
+	if expected, actual := "503,503,429,200", strings.Join(statusCodes, ","); actual != expected {
+		t.Fatalf("expected: %s actual: %s", expected, actual)
+	}
+
+	// The faults have been consumed, so the request without retries succeeds.
+	if _, err := server.Client().GetStatuses(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFaultRateLimited(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.Inject(RateLimited(1, time.Now().Add(time.Hour)))
+
+	_, err := server.Client().GetStatuses()
+	if e, ok := err.(backlog.Error); !ok || e.Message != "Too many requests." {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestFaultBody(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	client := server.Client()
+
+	server.Inject(BrokenErrorResponse(1))
+
+	if _, err := client.GetStatuses(); err == nil || err.Error() != "error response is broken" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	server.Inject(MalformedJSON(1))
+
+	if _, err := client.GetStatuses(); err == nil || !strings.Contains(err.Error(), "unexpected end of JSON input") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	server.Inject(TruncatedBody(1))
+
+	if _, err := client.GetStatuses(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestFaultSelector(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.Inject(Fault{Method: "GET", Path: "priorities", StatusCode: 503, Body: `{"errors":[]}`})
+
+	client := server.Client()
+
+	if _, err := client.GetStatuses(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetPriorities(); err == nil {
+		t.Fatal("expected error")
+	}
+
+	server.ClearFaults()
+
+	if _, err := client.GetPriorities(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFaultLatency(t *testing.T) {
+	server := NewServer()
+
+	server.Inject(Latency(10 * time.Second))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := server.Client().GetStatusesContext(ctx); err == nil {
+		t.Fatal("expected deadline error")
+	}
+
+	// The handler stops waiting when the request is canceled, so Close returns soon.
+	started := time.Now()
+	server.Close()
+
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("Close took %v", elapsed)
+	}
+}
@@ -0,0 +1,626 @@
+package backlogtest
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	backlog "github.com/moutend/go-backlog"
+)
+
+// project returns the project by the id or the key.
+func (s *Server) project(idOrKey string) *backlog.Project {
+	for _, project := range s.projects {
+		if strconv.Itoa(project.Id) == idOrKey || project.ProjectKey == idOrKey {
+			return project
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) user(id int) *backlog.User {
+	for _, user := range s.users {
+		if user.Id == id {
+			return user
+		}
+	}
+
+	return nil
+}
+
+// issue returns the issue by the id or the key.
+func (s *Server) issue(idOrKey string) *backlog.Issue {
+	for _, issue := range s.issues {
+		if strconv.Itoa(issue.Id) == idOrKey || issue.IssueKey == idOrKey {
+			return issue
+		}
+	}
+
+	return nil
+}
+
+// repository returns the repository by the id or the name in the project.
+func (s *Server) repository(projectIdOrKey, idOrName string) *backlog.Repository {
+	project := s.project(projectIdOrKey)
+	if project == nil {
+		return nil
+	}
+	for _, repository := range s.repositories {
+		if repository.ProjectId != project.Id {
+			continue
+		}
+		if strconv.Itoa(repository.Id) == idOrName || repository.Name == idOrName {
+			return repository
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) getProject(w http.ResponseWriter, idOrKey string) {
+	project := s.project(idOrKey)
+	if project == nil {
+		writeError(w, http.StatusNotFound, 6, "No project.")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, project)
+}
+
+func (s *Server) getIssueTypes(w http.ResponseWriter, idOrKey string) {
+	project := s.project(idOrKey)
+	if project == nil {
+		writeError(w, http.StatusNotFound, 6, "No project.")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.issueTypes[project.Id])
+}
+
+// filterIssues returns the issues matched with the query, ordered by the query.
+func (s *Server) filterIssues(query url.Values) []*backlog.Issue {
+	projectIds := intValues(query, "projectId[]")
+	statusIds := intValues(query, "statusId[]")
+	assigneeIds := intValues(query, "assigneeId[]")
+	issueTypeIds := intValues(query, "issueTypeId[]")
+	priorityIds := intValues(query, "priorityId[]")
+	keyword := query.Get("keyword")
+
+	issues := []*backlog.Issue{}
+
+	for _, issue := range s.issues {
+		if len(projectIds) > 0 && !projectIds[issue.ProjectId] {
+			continue
+		}
+		if len(statusIds) > 0 && !statusIds[issue.Status.Id] {
+			continue
+		}
+		if len(assigneeIds) > 0 && !assigneeIds[issue.Assignee.Id] {
+			continue
+		}
+		if len(issueTypeIds) > 0 && !issueTypeIds[issue.IssueType.Id] {
+			continue
+		}
+		if len(priorityIds) > 0 && !priorityIds[issue.Priority.Id] {
+			continue
+		}
+		if keyword != "" && !strings.Contains(issue.Summary, keyword) && !strings.Contains(issue.Description, keyword) {
+			continue
+		}
+
+		issues = append(issues, issue)
+	}
+
+	// The issues are stored in the order of creation, and Backlog returns the newest first by default.
+	if query.Get("order") != "asc" {
+		sort.SliceStable(issues, func(i, j int) bool {
+			return issues[i].Id > issues[j].Id
+		})
+	}
+
+	return issues
+}
+
+func (s *Server) getIssues(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	issues := s.filterIssues(query)
+	start, end := page(query, len(issues))
+
+	writeJSON(w, http.StatusOK, issues[start:end])
+}
+
+func (s *Server) countIssues(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]int{
+		"count": len(s.filterIssues(r.URL.Query())),
+	})
+}
+
+func (s *Server) createIssue(w http.ResponseWriter, r *http.Request) {
+	if !required(w, r, "projectId", "summary", "issueTypeId", "priorityId") {
+		return
+	}
+
+	project := s.project(r.PostForm.Get("projectId"))
+	if project == nil {
+		writeError(w, http.StatusBadRequest, 7, "No project.")
+		return
+	}
+
+	issue := &backlog.Issue{
+		Id:          s.id(),
+		ProjectId:   project.Id,
+		Summary:     r.PostForm.Get("summary"),
+		Description: r.PostForm.Get("description"),
+		Status:      s.statuses[0],
+		CreatedUser: *s.myself,
+		Created:     s.now(),
+		UpdateUser:  *s.myself,
+		Updated:     s.now(),
+	}
+	if !s.setIssueFields(w, r, issue) {
+		return
+	}
+
+	s.keyIds[project.Id]++
+	issue.KeyId = s.keyIds[project.Id]
+	issue.IssueKey = project.ProjectKey + "-" + strconv.Itoa(issue.KeyId)
+	s.issues = append(s.issues, issue)
+
+	writeJSON(w, http.StatusCreated, issue)
+}
+
+// setIssueFields sets the issue type, the priority, the status and the assignee given in the form. It writes the error and returns false if any of them does not exist.
+func (s *Server) setIssueFields(w http.ResponseWriter, r *http.Request, issue *backlog.Issue) bool {
+	if value := r.PostForm.Get("issueTypeId"); value != "" {
+		found := false
+		for _, issueType := range s.issueTypes[issue.ProjectId] {
+			if strconv.Itoa(issueType.Id) == value {
+				issue.IssueType, found = issueType, true
+			}
+		}
+		if !found {
+			writeError(w, http.StatusBadRequest, 7, "No issue type.")
+			return false
+		}
+	}
+	if value := r.PostForm.Get("priorityId"); value != "" {
+		found := false
+		for _, priority := range s.priorities {
+			if strconv.Itoa(priority.Id) == value {
+				issue.Priority, found = priority, true
+			}
+		}
+		if !found {
+			writeError(w, http.StatusBadRequest, 7, "No priority.")
+			return false
+		}
+	}
+	if value := r.PostForm.Get("statusId"); value != "" {
+		found := false
+		for _, status := range s.statuses {
+			if strconv.Itoa(status.Id) == value {
+				issue.Status, found = status, true
+			}
+		}
+		if !found {
+			writeError(w, http.StatusBadRequest, 7, "No status.")
+			return false
+		}
+	}
+	if value := r.PostForm.Get("assigneeId"); value != "" {
+		id, _ := strconv.Atoi(value)
+		user := s.user(id)
+		if user == nil {
+			writeError(w, http.StatusBadRequest, 7, "No assignee.")
+			return false
+		}
+		issue.Assignee = *user
+	}
+
+	return true
+}
+
+func (s *Server) getIssue(w http.ResponseWriter, idOrKey string) {
+	issue := s.issue(idOrKey)
+	if issue == nil {
+		writeError(w, http.StatusNotFound, 6, "No issue.")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, issue)
+}
+
+// updateIssue updates the issue and adds the comment with the change logs, as Backlog does.
+func (s *Server) updateIssue(w http.ResponseWriter, r *http.Request, idOrKey string) {
+	issue := s.issue(idOrKey)
+	if issue == nil {
+		writeError(w, http.StatusNotFound, 6, "No issue.")
+		return
+	}
+
+	updated := *issue
+	if value, ok := r.PostForm["summary"]; ok {
+		updated.Summary = value[0]
+	}
+	if value, ok := r.PostForm["description"]; ok {
+		updated.Description = value[0]
+	}
+	if !s.setIssueFields(w, r, &updated) {
+		return
+	}
+
+	changeLogs := []backlog.ChangeLog{}
+	add := func(field backlog.ChangeLogField, original, value string) {
+		if original != value {
+			changeLogs = append(changeLogs, backlog.ChangeLog{
+				Field:         field,
+				OriginalValue: original,
+				NewValue:      value,
+			})
+		}
+	}
+
+	add(backlog.ChangeLogFieldSummary, issue.Summary, updated.Summary)
+	add(backlog.ChangeLogFieldDescription, issue.Description, updated.Description)
+	add(backlog.ChangeLogFieldIssueType, issue.IssueType.Name, updated.IssueType.Name)
+	add(backlog.ChangeLogFieldPriority, issue.Priority.Name, updated.Priority.Name)
+	add(backlog.ChangeLogFieldStatus, issue.Status.Name, updated.Status.Name)
+	add(backlog.ChangeLogFieldAssigner, issue.Assignee.Name, updated.Assignee.Name)
+
+	updated.UpdateUser = *s.myself
+	updated.Updated = s.now()
+	*issue = updated
+
+	if len(changeLogs) > 0 || r.PostForm.Get("comment") != "" {
+		s.comments[issue.Id] = append(s.comments[issue.Id], &backlog.Comment{
+			Id:          s.id(),
+			Content:     r.PostForm.Get("comment"),
+			ChangeLog:   changeLogs,
+			CreatedUser: *s.myself,
+			Created:     s.now(),
+			Updated:     s.now(),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, issue)
+}
+
+func (s *Server) deleteIssue(w http.ResponseWriter, idOrKey string) {
+	issue := s.issue(idOrKey)
+	if issue == nil {
+		writeError(w, http.StatusNotFound, 6, "No issue.")
+		return
+	}
+	for i := range s.issues {
+		if s.issues[i] == issue {
+			s.issues = append(s.issues[:i], s.issues[i+1:]...)
+			break
+		}
+	}
+
+	delete(s.comments, issue.Id)
+
+	writeJSON(w, http.StatusOK, issue)
+}
+
+func (s *Server) getComments(w http.ResponseWriter, r *http.Request, idOrKey string) {
+	issue := s.issue(idOrKey)
+	if issue == nil {
+		writeError(w, http.StatusNotFound, 6, "No issue.")
+		return
+	}
+
+	query := r.URL.Query()
+	minId, _ := strconv.Atoi(query.Get("minId"))
+	maxId, _ := strconv.Atoi(query.Get("maxId"))
+	comments := []*backlog.Comment{}
+
+	for _, comment := range s.comments[issue.Id] {
+		if minId > 0 && comment.Id < minId {
+			continue
+		}
+		if maxId > 0 && comment.Id > maxId {
+			continue
+		}
+
+		comments = append(comments, comment)
+	}
+	if query.Get("order") != "asc" {
+		sort.SliceStable(comments, func(i, j int) bool {
+			return comments[i].Id > comments[j].Id
+		})
+	}
+
+	// The comments are not paged by offset but only limited by count.
+	query.Del("offset")
+	_, end := page(query, len(comments))
+
+	writeJSON(w, http.StatusOK, comments[:end])
+}
+
+func (s *Server) addComment(w http.ResponseWriter, r *http.Request, idOrKey string) {
+	issue := s.issue(idOrKey)
+	if issue == nil {
+		writeError(w, http.StatusNotFound, 6, "No issue.")
+		return
+	}
+	if !required(w, r, "content") {
+		return
+	}
+
+	comment := &backlog.Comment{
+		Id:          s.id(),
+		Content:     r.PostForm.Get("content"),
+		ChangeLog:   []backlog.ChangeLog{},
+		CreatedUser: *s.myself,
+		Created:     s.now(),
+		Updated:     s.now(),
+	}
+	s.comments[issue.Id] = append(s.comments[issue.Id], comment)
+
+	writeJSON(w, http.StatusCreated, comment)
+}
+
+func (s *Server) countComments(w http.ResponseWriter, idOrKey string) {
+	issue := s.issue(idOrKey)
+	if issue == nil {
+		writeError(w, http.StatusNotFound, 6, "No issue.")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]int{
+		"count": len(s.comments[issue.Id]),
+	})
+}
+
+// comment returns the index of the comment in the comments of the issue, or -1 if it does not exist.
+func (s *Server) comment(w http.ResponseWriter, idOrKey, commentId string) (*backlog.Issue, int) {
+	issue := s.issue(idOrKey)
+	if issue == nil {
+		writeError(w, http.StatusNotFound, 6, "No issue.")
+		return nil, -1
+	}
+	for i, comment := range s.comments[issue.Id] {
+		if strconv.Itoa(comment.Id) == commentId {
+			return issue, i
+		}
+	}
+
+	writeError(w, http.StatusNotFound, 6, "No comment.")
+
+	return nil, -1
+}
+
+func (s *Server) getComment(w http.ResponseWriter, idOrKey, commentId string) {
+	issue, i := s.comment(w, idOrKey, commentId)
+	if i < 0 {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.comments[issue.Id][i])
+}
+
+func (s *Server) updateComment(w http.ResponseWriter, r *http.Request, idOrKey, commentId string) {
+	issue, i := s.comment(w, idOrKey, commentId)
+	if i < 0 {
+		return
+	}
+	if !required(w, r, "content") {
+		return
+	}
+
+	comment := s.comments[issue.Id][i]
+	comment.Content = r.PostForm.Get("content")
+	comment.Updated = s.now()
+
+	writeJSON(w, http.StatusOK, comment)
+}
+
+func (s *Server) deleteComment(w http.ResponseWriter, idOrKey, commentId string) {
+	issue, i := s.comment(w, idOrKey, commentId)
+	if i < 0 {
+		return
+	}
+
+	comment := s.comments[issue.Id][i]
+	s.comments[issue.Id] = append(s.comments[issue.Id][:i], s.comments[issue.Id][i+1:]...)
+
+	writeJSON(w, http.StatusOK, comment)
+}
+
+func (s *Server) getRepositories(w http.ResponseWriter, projectIdOrKey string) {
+	project := s.project(projectIdOrKey)
+	if project == nil {
+		writeError(w, http.StatusNotFound, 6, "No project.")
+		return
+	}
+
+	repositories := []*backlog.Repository{}
+	for _, repository := range s.repositories {
+		if repository.ProjectId == project.Id {
+			repositories = append(repositories, repository)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, repositories)
+}
+
+func (s *Server) getRepository(w http.ResponseWriter, projectIdOrKey, idOrName string) {
+	repository := s.repository(projectIdOrKey, idOrName)
+	if repository == nil {
+		writeError(w, http.StatusNotFound, 6, "No repository.")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, repository)
+}
+
+// filterPullRequests returns the pull requests matched with the query, newest first.
+func (s *Server) filterPullRequests(query url.Values, repository *backlog.Repository) []*backlog.PullRequest {
+	statusIds := intValues(query, "statusId[]")
+	assigneeIds := intValues(query, "assigneeId[]")
+	issueIds := intValues(query, "issueId[]")
+	createdUserIds := intValues(query, "createdUserId[]")
+
+	pullRequests := []*backlog.PullRequest{}
+
+	for _, pullRequest := range s.pullRequests[repository.Id] {
+		if len(statusIds) > 0 && !statusIds[int(pullRequest.Status)] {
+			continue
+		}
+		if len(assigneeIds) > 0 && !assigneeIds[pullRequest.Assignee.Id] {
+			continue
+		}
+		if len(issueIds) > 0 && !issueIds[pullRequest.Issue.Id] {
+			continue
+		}
+		if len(createdUserIds) > 0 && !createdUserIds[pullRequest.CreatedUser.Id] {
+			continue
+		}
+
+		pullRequests = append(pullRequests, pullRequest)
+	}
+
+	sort.SliceStable(pullRequests, func(i, j int) bool {
+		return pullRequests[i].Number > pullRequests[j].Number
+	})
+
+	return pullRequests
+}
+
+func (s *Server) getPullRequests(w http.ResponseWriter, r *http.Request, projectIdOrKey, idOrName string) {
+	repository := s.repository(projectIdOrKey, idOrName)
+	if repository == nil {
+		writeError(w, http.StatusNotFound, 6, "No repository.")
+		return
+	}
+
+	query := r.URL.Query()
+	pullRequests := s.filterPullRequests(query, repository)
+	start, end := page(query, len(pullRequests))
+
+	writeJSON(w, http.StatusOK, pullRequests[start:end])
+}
+
+func (s *Server) countPullRequests(w http.ResponseWriter, r *http.Request, projectIdOrKey, idOrName string) {
+	repository := s.repository(projectIdOrKey, idOrName)
+	if repository == nil {
+		writeError(w, http.StatusNotFound, 6, "No repository.")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]int{
+		"count": len(s.filterPullRequests(r.URL.Query(), repository)),
+	})
+}
+
+func (s *Server) createPullRequest(w http.ResponseWriter, r *http.Request, projectIdOrKey, idOrName string) {
+	repository := s.repository(projectIdOrKey, idOrName)
+	if repository == nil {
+		writeError(w, http.StatusNotFound, 6, "No repository.")
+		return
+	}
+	if !required(w, r, "summary", "description", "base", "branch") {
+		return
+	}
+
+	pullRequest := &backlog.PullRequest{
+		Id:           s.id(),
+		ProjectId:    repository.ProjectId,
+		RepositoryId: repository.Id,
+		Number:       len(s.pullRequests[repository.Id]) + 1,
+		Summary:      r.PostForm.Get("summary"),
+		Description:  r.PostForm.Get("description"),
+		Base:         r.PostForm.Get("base"),
+		Branch:       r.PostForm.Get("branch"),
+		Status:       backlog.PullRequestStatusOpen,
+		CreatedUser:  *s.myself,
+		Created:      s.now(),
+		UpdatedUser:  *s.myself,
+		Updated:      s.now(),
+	}
+	if !s.setPullRequestFields(w, r, pullRequest) {
+		return
+	}
+
+	s.pullRequests[repository.Id] = append(s.pullRequests[repository.Id], pullRequest)
+
+	writeJSON(w, http.StatusCreated, pullRequest)
+}
+
+// setPullRequestFields sets the issue and the assignee given in the form. It writes the error and returns false if any of them does not exist.
+func (s *Server) setPullRequestFields(w http.ResponseWriter, r *http.Request, pullRequest *backlog.PullRequest) bool {
+	if value := r.PostForm.Get("issueId"); value != "" {
+		issue := s.issue(value)
+		if issue == nil {
+			writeError(w, http.StatusBadRequest, 7, "No issue.")
+			return false
+		}
+		pullRequest.Issue = *issue
+	}
+	if value := r.PostForm.Get("assigneeId"); value != "" {
+		id, _ := strconv.Atoi(value)
+		user := s.user(id)
+		if user == nil {
+			writeError(w, http.StatusBadRequest, 7, "No assignee.")
+			return false
+		}
+		pullRequest.Assignee = *user
+	}
+
+	return true
+}
+
+// pullRequest returns the pull request by the number. It writes the error and returns nil if it does not exist.
+func (s *Server) pullRequest(w http.ResponseWriter, projectIdOrKey, idOrName, number string) *backlog.PullRequest {
+	repository := s.repository(projectIdOrKey, idOrName)
+	if repository == nil {
+		writeError(w, http.StatusNotFound, 6, "No repository.")
+		return nil
+	}
+	for _, pullRequest := range s.pullRequests[repository.Id] {
+		if strconv.Itoa(pullRequest.Number) == number {
+			return pullRequest
+		}
+	}
+
+	writeError(w, http.StatusNotFound, 6, "No pull request.")
+
+	return nil
+}
+
+func (s *Server) getPullRequest(w http.ResponseWriter, projectIdOrKey, idOrName, number string) {
+	pullRequest := s.pullRequest(w, projectIdOrKey, idOrName, number)
+	if pullRequest == nil {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, pullRequest)
+}
+
+func (s *Server) updatePullRequest(w http.ResponseWriter, r *http.Request, projectIdOrKey, idOrName, number string) {
+	pullRequest := s.pullRequest(w, projectIdOrKey, idOrName, number)
+	if pullRequest == nil {
+		return
+	}
+
+	updated := *pullRequest
+	if value := r.PostForm.Get("summary"); value != "" {
+		updated.Summary = value
+	}
+	if value := r.PostForm.Get("description"); value != "" {
+		updated.Description = value
+	}
+	if !s.setPullRequestFields(w, r, &updated) {
+		return
+	}
+
+	updated.UpdatedUser = *s.myself
+	updated.Updated = s.now()
+	*pullRequest = updated
+
+	writeJSON(w, http.StatusOK, pullRequest)
+}
@@ -0,0 +1,377 @@
+package backlogtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	backlog "github.com/moutend/go-backlog"
+)
+
+// DefaultAPIKey is the API key accepted by the Server unless APIKey is changed.
+const DefaultAPIKey = "backlogtest"
+
+// Server emulates the Backlog API v2 in memory. It supports projects, issue types, statuses, priorities, users, issues, comments, repositories and pull requests, with the filtering, paging and errors in the same form as Backlog. The failures can be injected with Inject.
+//
+//	server := backlogtest.NewServer()
+//	defer server.Close()
+//
+//	project := server.AddProject("SAMPLE", "Sample")
+//	client := server.Client()
+type Server struct {
+	*httptest.Server
+
+	// APIKey is compared with the apiKey parameter of each request.
+	APIKey string
+	// Now returns the time recorded as created and updated.
+	Now func() time.Time
+
+	mu           sync.Mutex
+	nextId       int
+	myself       *backlog.User
+	users        []*backlog.User
+	statuses     []backlog.Status
+	priorities   []backlog.Priority
+	projects     []*backlog.Project
+	issueTypes   map[int][]backlog.IssueType
+	keyIds       map[int]int
+	issues       []*backlog.Issue
+	comments     map[int][]*backlog.Comment
+	repositories []*backlog.Repository
+	pullRequests map[int][]*backlog.PullRequest
+
+	faultsMu sync.Mutex
+	faults   []*Fault
+}
+
+// NewServer starts the Server. The authenticated user, the statuses and the priorities are created in advance.
+func NewServer() *Server {
+	s := &Server{
+		APIKey:       DefaultAPIKey,
+		Now:          time.Now,
+		nextId:       1,
+		issueTypes:   map[int][]backlog.IssueType{},
+		keyIds:       map[int]int{},
+		comments:     map[int][]*backlog.Comment{},
+		pullRequests: map[int][]*backlog.PullRequest{},
+		statuses: []backlog.Status{
+			{Id: 1, Name: "Open"},
+			{Id: 2, Name: "In Progress"},
+			{Id: 3, Name: "Resolved"},
+			{Id: 4, Name: "Closed"},
+		},
+		priorities: []backlog.Priority{
+			{Id: 2, Name: "High"},
+			{Id: 3, Name: "Normal"},
+			{Id: 4, Name: "Low"},
+		},
+	}
+
+	s.myself = s.AddUser("admin", "Admin")
+	s.Server = httptest.NewServer(s)
+
+	return s
+}
+
+// Client returns the client which sends the requests to the Server with APIKey.
+func (s *Server) Client() *backlog.Client {
+	client, _ := backlog.New("backlogtest", s.APIKey)
+	client.SetHTTPClient(&http.Client{Transport: s.Transport()})
+
+	return client
+}
+
+// Transport returns the transport which sends the requests to the Server instead of the space. It can be used to build the client with another API key.
+func (s *Server) Transport() http.RoundTripper {
+	target, _ := url.Parse(s.URL)
+
+	return redirect{target}
+}
+
+// Myself returns the user authenticated by APIKey.
+func (s *Server) Myself() *backlog.User {
+	return s.myself
+}
+
+// AddUser creates the user.
+func (s *Server) AddUser(userId, name string) *backlog.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user := &backlog.User{
+		Id:       s.id(),
+		UserId:   userId,
+		Name:     name,
+		RoleType: 1,
+		Lang:     "en",
+	}
+	s.users = append(s.users, user)
+
+	return user
+}
+
+// AddProject creates the project with the issue types Bug, Task, Request and Other.
+func (s *Server) AddProject(projectKey, name string) *backlog.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project := &backlog.Project{
+		Id:                 s.id(),
+		ProjectKey:         projectKey,
+		Name:               name,
+		TextFormattingRule: backlog.TextFormattingRuleMarkdown,
+		UseWiki:            true,
+		UseFileSharing:     true,
+		UseDevAttributes:   true,
+	}
+	s.projects = append(s.projects, project)
+
+	for i, name := range []string{"Bug", "Task", "Request", "Other"} {
+		s.issueTypes[project.Id] = append(s.issueTypes[project.Id], backlog.IssueType{
+			Id:           s.id(),
+			ProjectId:    project.Id,
+			Name:         name,
+			DisplayOrder: i,
+		})
+	}
+
+	return project
+}
+
+// AddRepository creates the Git repository in the project.
+func (s *Server) AddRepository(projectKey, name string) *backlog.Repository {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project := s.project(projectKey)
+	if project == nil {
+		panic("backlogtest: no project " + projectKey)
+	}
+
+	repository := &backlog.Repository{
+		Id:          s.id(),
+		ProjectId:   project.Id,
+		Name:        name,
+		HTTPURL:     fmt.Sprintf("https://backlogtest.backlog.jp/git/%s/%s.git", projectKey, name),
+		SSHURL:      fmt.Sprintf("backlogtest@backlogtest.git.backlog.jp:/%s/%s.git", projectKey, name),
+		CreatedUser: *s.myself,
+		Created:     s.now(),
+		UpdatedUser: *s.myself,
+		Updated:     s.now(),
+	}
+	s.repositories = append(s.repositories, repository)
+
+	return repository
+}
+
+// SetPullRequestStatus changes the status of the pull request, which cannot be done with the API. It returns false if the pull request does not exist.
+func (s *Server) SetPullRequestStatus(repository *backlog.Repository, number int, status backlog.PullRequestStatus) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, pullRequest := range s.pullRequests[repository.Id] {
+		if pullRequest.Number != number {
+			continue
+		}
+
+		pullRequest.Status = status
+		pullRequest.Updated = s.now()
+
+		switch status {
+		case backlog.PullRequestStatusClosed:
+			pullRequest.CloseAt = s.now()
+		case backlog.PullRequestStatusMerged:
+			pullRequest.CloseAt = s.now()
+			pullRequest.MergeAt = s.now()
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.serveFaults(w, r) {
+		return
+	}
+
+	s.serve(w, r)
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Query().Get("apiKey") != s.APIKey {
+		writeError(w, http.StatusUnauthorized, 11, "Authentication failure.")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, 7, err.Error())
+		return
+	}
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v2"), "/")
+	segments := strings.Split(path, "/")
+
+	if !s.route(w, r, segments) {
+		writeError(w, http.StatusNotFound, 6, "No such resource.")
+	}
+}
+
+// route dispatches the request by the path segments. It returns false if no handler matches.
+func (s *Server) route(w http.ResponseWriter, r *http.Request, segments []string) bool {
+	method := r.Method
+	n := len(segments)
+
+	switch {
+	case n == 1 && segments[0] == "statuses" && method == "GET":
+		writeJSON(w, http.StatusOK, s.statuses)
+	case n == 1 && segments[0] == "priorities" && method == "GET":
+		writeJSON(w, http.StatusOK, s.priorities)
+	case n == 1 && segments[0] == "users" && method == "GET":
+		writeJSON(w, http.StatusOK, s.users)
+	case n == 2 && segments[0] == "users" && segments[1] == "myself" && method == "GET":
+		writeJSON(w, http.StatusOK, s.myself)
+	case n == 1 && segments[0] == "projects" && method == "GET":
+		writeJSON(w, http.StatusOK, s.projects)
+	case n == 2 && segments[0] == "projects" && method == "GET":
+		s.getProject(w, segments[1])
+	case n == 3 && segments[0] == "projects" && segments[2] == "issueTypes" && method == "GET":
+		s.getIssueTypes(w, segments[1])
+	case n == 1 && segments[0] == "issues" && method == "GET":
+		s.getIssues(w, r)
+	case n == 2 && segments[0] == "issues" && segments[1] == "count" && method == "GET":
+		s.countIssues(w, r)
+	case n == 1 && segments[0] == "issues" && method == "POST":
+		s.createIssue(w, r)
+	case n == 2 && segments[0] == "issues" && method == "GET":
+		s.getIssue(w, segments[1])
+	case n == 2 && segments[0] == "issues" && method == "PATCH":
+		s.updateIssue(w, r, segments[1])
+	case n == 2 && segments[0] == "issues" && method == "DELETE":
+		s.deleteIssue(w, segments[1])
+	case n == 3 && segments[0] == "issues" && segments[2] == "comments" && method == "GET":
+		s.getComments(w, r, segments[1])
+	case n == 3 && segments[0] == "issues" && segments[2] == "comments" && method == "POST":
+		s.addComment(w, r, segments[1])
+	case n == 4 && segments[0] == "issues" && segments[2] == "comments" && segments[3] == "count" && method == "GET":
+		s.countComments(w, segments[1])
+	case n == 4 && segments[0] == "issues" && segments[2] == "comments" && method == "GET":
+		s.getComment(w, segments[1], segments[3])
+	case n == 4 && segments[0] == "issues" && segments[2] == "comments" && method == "PATCH":
+		s.updateComment(w, r, segments[1], segments[3])
+	case n == 4 && segments[0] == "issues" && segments[2] == "comments" && method == "DELETE":
+		s.deleteComment(w, segments[1], segments[3])
+	case n == 4 && segments[0] == "projects" && segments[2] == "git" && segments[3] == "repositories" && method == "GET":
+		s.getRepositories(w, segments[1])
+	case n == 5 && segments[0] == "projects" && segments[2] == "git" && segments[3] == "repositories" && method == "GET":
+		s.getRepository(w, segments[1], segments[4])
+	case n == 6 && segments[0] == "projects" && segments[2] == "git" && segments[5] == "pullRequests" && method == "GET":
+		s.getPullRequests(w, r, segments[1], segments[4])
+	case n == 6 && segments[0] == "projects" && segments[2] == "git" && segments[5] == "pullRequests" && method == "POST":
+		s.createPullRequest(w, r, segments[1], segments[4])
+	case n == 7 && segments[0] == "projects" && segments[2] == "git" && segments[5] == "pullRequests" && segments[6] == "count" && method == "GET":
+		s.countPullRequests(w, r, segments[1], segments[4])
+	case n == 7 && segments[0] == "projects" && segments[2] == "git" && segments[5] == "pullRequests" && method == "GET":
+		s.getPullRequest(w, segments[1], segments[4], segments[6])
+	case n == 7 && segments[0] == "projects" && segments[2] == "git" && segments[5] == "pullRequests" && method == "PATCH":
+		s.updatePullRequest(w, r, segments[1], segments[4], segments[6])
+	default:
+		return false
+	}
+
+	return true
+}
+
+// id returns the id unique in the Server.
+func (s *Server) id() int {
+	id := s.nextId
+	s.nextId++
+
+	return id
+}
+
+func (s *Server) now() backlog.Date {
+	return backlog.Date(s.Now().UTC().Format(time.RFC3339))
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes the error in the same form as Backlog.
+func writeError(w http.ResponseWriter, statusCode, code int, message string) {
+	writeJSON(w, statusCode, backlog.Errors{
+		Errors: []backlog.Error{{Message: message, Code: code}},
+	})
+}
+
+// required writes the error and returns false if any of the keys is missing from the form.
+func required(w http.ResponseWriter, r *http.Request, keys ...string) bool {
+	for _, key := range keys {
+		if r.PostForm.Get(key) == "" {
+			writeError(w, http.StatusBadRequest, 7, fmt.Sprintf("Please input '%s'.", key))
+			return false
+		}
+	}
+
+	return true
+}
+
+// intValues returns the integer values of the key. The values which are not integers are ignored.
+func intValues(values url.Values, key string) map[int]bool {
+	ints := map[int]bool{}
+
+	for _, value := range values[key] {
+		if i, err := strconv.Atoi(value); err == nil {
+			ints[i] = true
+		}
+	}
+
+	return ints
+}
+
+// page returns the range of the items selected by offset and count.
+func page(query url.Values, total int) (int, int) {
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	count, err := strconv.Atoi(query.Get("count"))
+
+	if err != nil || count <= 0 {
+		count = 20
+	}
+	if count > 100 {
+		count = 100
+	}
+	if offset < 0 || offset > total {
+		offset = total
+	}
+	if offset+count > total {
+		return offset, total
+	}
+
+	return offset, offset + count
+}
+
+// redirect sends the requests to the target instead of the space.
+type redirect struct {
+	target *url.URL
+}
+
+func (r redirect) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The request must not be modified by RoundTrip, so the clone is sent instead.
+	req = req.Clone(req.Context())
+	req.URL.Scheme = r.target.Scheme
+	req.URL.Host = r.target.Host
+
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -0,0 +1,109 @@
+package backlogtest
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	backlog "github.com/moutend/go-backlog"
+)
+
+func TestServerIssues(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	project := server.AddProject("SAMPLE", "Sample")
+	client := server.Client()
+
+	issueTypes, err := client.GetIssueTypes(project.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		values := url.Values{}
+		values.Set("projectId", strconv.Itoa(project.Id))
+		values.Set("summary", "issue "+strconv.Itoa(i))
+		values.Set("issueTypeId", strconv.Itoa(issueTypes[0].Id))
+		values.Set("priorityId", "3")
+
+		issue, err := client.CreateIssue(values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := "SAMPLE-" + strconv.Itoa(i+1); issue.IssueKey != expected {
+			t.Fatalf("expected: %s actual: %s", expected, issue.IssueKey)
+		}
+	}
+
+	values := url.Values{}
+	values.Set("statusId", "2")
+	values.Set("assigneeId", strconv.Itoa(server.Myself().Id))
+
+	if _, err := client.SetIssue("SAMPLE-2", values); err != nil {
+		t.Fatal(err)
+	}
+
+	query := url.Values{}
+	query.Add("statusId[]", "2")
+
+	issues, err := client.GetIssues(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].IssueKey != "SAMPLE-2" {
+		t.Fatalf("unexpected issues: %+v", issues)
+	}
+
+	query = url.Values{}
+	query.Set("count", "2")
+	query.Set("offset", "1")
+
+	issues, err = client.GetIssues(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 || issues[0].IssueKey != "SAMPLE-2" || issues[1].IssueKey != "SAMPLE-1" {
+		t.Fatalf("unexpected issues: %+v", issues)
+	}
+
+	comments, err := client.GetComments("SAMPLE-2", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 1 || len(comments[0].ChangeLog) != 2 {
+		t.Fatalf("unexpected comments: %+v", comments)
+	}
+	if changeLog := comments[0].ChangeLog[0]; changeLog.Field != backlog.ChangeLogFieldStatus || changeLog.NewValue != "In Progress" {
+		t.Fatalf("unexpected change log: %+v", changeLog)
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.AddProject("SAMPLE", "Sample")
+
+	_, err := server.Client().CreateIssue(url.Values{"projectId": {"SAMPLE"}})
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	if expected := "CreateIssueContext: Please input 'summary'. (InvalidRequestError)"; err.Error() != expected {
+		t.Fatalf("expected: %s actual: %s", expected, err)
+	}
+
+	_, err = server.Client().GetIssue("SAMPLE-1")
+	if e, ok := err.(backlog.Error); !ok || e.Code != 6 {
+		t.Fatalf("expected NoResourceError, got %v", err)
+	}
+
+	client, _ := backlog.New("backlogtest", "wrong")
+	client.SetHTTPClient(&http.Client{Transport: server.Transport()})
+
+	_, err = client.GetProjects(nil)
+	if e, ok := err.(backlog.Error); !ok || e.Code != 11 {
+		t.Fatalf("expected AuthenticationError, got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package backlogtest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	backlog "github.com/moutend/go-backlog"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v2/statuses":
+			w.Write([]byte(`[{"id":1,"name":"Open"}]`))
+		case "POST /api/v2/issues/SAMPLE-1/comments":
+			w.Write([]byte(`{"id":67890,"content":"deployed"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	target, _ := url.Parse(server.URL)
+	recorder := NewRecorder(redirect{target})
+
+	client, _ := backlog.New("spaceName", "XXXXXXXX")
+	client.SetHTTPClient(&http.Client{Transport: recorder})
+
+	if _, err := client.GetStatuses(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.AddComment("SAMPLE-1", "deployed", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "backlogtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "cassette.json")
+
+	if err = recorder.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "XXXXXXXX") {
+		t.Fatalf("API key is recorded: %s", data)
+	}
+
+	replayer, err := LoadReplayer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, _ = backlog.New("spaceName", "YYYYYYYY")
+	client.SetHTTPClient(&http.Client{Transport: replayer})
+
+	comment, err := client.AddComment("SAMPLE-1", "deployed", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comment.Id != 67890 {
+		t.Fatalf("expected 67890, got %d", comment.Id)
+	}
+	if _, err = client.AddComment("SAMPLE-1", "rolled back", nil, nil); err == nil {
+		t.Fatal("expected error for the unrecorded body")
+	}
+	if unused := replayer.Unused(); len(unused) != 1 || unused[0].Request.Path != "/api/v2/statuses" {
+		t.Fatalf("unexpected unused interactions %+v", unused)
+	}
+}
+
+func TestScrub(t *testing.T) {
+	req, _ := http.NewRequest("POST", "https://example.backlog.jp/api/v2/oauth2/token?apiKey=XXXXXXXX", strings.NewReader("grant_type=authorization_code&code=s3cret"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	request := newRequest(req, []byte("grant_type=authorization_code&code=s3cret"))
+
+	if strings.Contains(request.Body, "s3cret") || request.Query.Get("apiKey") != "" {
+		t.Fatalf("secrets are recorded: %+v", request)
+	}
+}
+
+func TestReplayUpload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":1,"name":"build.log","size":5}`))
+	}))
+	defer server.Close()
+
+	target, _ := url.Parse(server.URL)
+	recorder := NewRecorder(redirect{target})
+
+	client, _ := backlog.New("spaceName", "XXXXXXXX")
+	client.SetHTTPClient(&http.Client{Transport: recorder})
+
+	if _, err := client.UploadAttachment("build.log", strings.NewReader("hello")); err != nil {
+		t.Fatal(err)
+	}
+
+	replayer := NewReplayer(recorder.Cassette())
+	client.SetHTTPClient(&http.Client{Transport: replayer})
+
+	attachment, err := client.UploadAttachment("build.log", strings.NewReader("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attachment.Id != 1 {
+		t.Fatalf("expected 1, got %d", attachment.Id)
+	}
+	if _, err = client.UploadAttachment("build.log", strings.NewReader("other")); err == nil {
+		t.Fatal("expected error for the unrecorded content")
+	}
+}
@@ -0,0 +1,171 @@
+// Package cache provides the middleware which caches the responses of backlog.Client.
+//
+// Only the GET requests which match one of the rules are cached. The cache key is the method and URL, including the space, without the API key. A successful POST, PATCH or DELETE purges all the entries in the same resource family, which is the first path segment such as "issues" or "projects". When an entry has expired and the response had ETag or Last-Modified, the request is revalidated with If-None-Match or If-Modified-Since.
+//
+//	c := cache.New(cache.NewMemoryStore(), cache.DefaultRules...)
+//	client.Use(c.Middleware())
+package cache
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	backlog "github.com/moutend/go-backlog"
+)
+
+// Rule sets the TTL of the responses whose path matches Pattern. The pattern is matched with path.Match against the path relative to "/api/v2/", such as "projects/*/issueTypes".
+type Rule struct {
+	Pattern string
+	TTL     time.Duration
+}
+
+// DefaultRules caches the master data which rarely changes.
+var DefaultRules = []Rule{
+	{"statuses", time.Hour},
+	{"priorities", time.Hour},
+	{"resolutions", time.Hour},
+	{"projects", 5 * time.Minute},
+	{"projects/*", 5 * time.Minute},
+	{"projects/*/statuses", time.Hour},
+	{"projects/*/issueTypes", time.Hour},
+	{"projects/*/categories", time.Hour},
+	{"projects/*/versions", time.Hour},
+	{"projects/*/customFields", time.Hour},
+	{"users", 5 * time.Minute},
+}
+
+type Cache struct {
+	store Store
+	rules []Rule
+	now   func() time.Time
+}
+
+func New(store Store, rules ...Rule) *Cache {
+	return &Cache{
+		store: store,
+		rules: rules,
+		now:   time.Now,
+	}
+}
+
+// Middleware returns the middleware to pass to backlog.Client.Use.
+func (c *Cache) Middleware() backlog.Middleware {
+	return func(next backlog.Doer) backlog.Doer {
+		return backlog.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			rel := backlog.RelativePath(req.URL.Path)
+			family := strings.SplitN(rel, "/", 2)[0]
+
+			if req.Method != http.MethodGet {
+				res, err := next.Do(req)
+				if err == nil && res.StatusCode >= 200 && res.StatusCode < 300 {
+					c.store.Purge(family)
+				}
+				return res, err
+			}
+
+			ttl, ok := c.ttl(rel)
+			if !ok {
+				return next.Do(req)
+			}
+
+			key := cacheKey(req)
+			entry, cached := c.store.Get(family, key)
+
+			if cached && c.now().Sub(entry.Stored) < ttl {
+				return entry.response(req), nil
+			}
+			if cached {
+				req = revalidate(req, entry)
+			}
+
+			res, err := next.Do(req)
+			if err != nil {
+				return nil, err
+			}
+			if cached && res.StatusCode == http.StatusNotModified {
+				res.Body.Close()
+				// The entry is shared with the store and the other requests, so the copy is stored.
+				refreshed := *entry
+				refreshed.Stored = c.now()
+				c.store.Set(family, key, &refreshed)
+				return refreshed.response(req), nil
+			}
+			if res.StatusCode != http.StatusOK {
+				return res, nil
+			}
+
+			body, err := ioutil.ReadAll(res.Body)
+			res.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+
+			res.Body = ioutil.NopCloser(bytes.NewReader(body))
+			c.store.Set(family, key, &Entry{
+				StatusCode: res.StatusCode,
+				Header:     res.Header.Clone(),
+				Body:       body,
+				Stored:     c.now(),
+			})
+
+			return res, nil
+		})
+	}
+}
+
+func (c *Cache) ttl(rel string) (time.Duration, bool) {
+	for _, rule := range c.rules {
+		if ok, _ := path.Match(rule.Pattern, rel); ok {
+			return rule.TTL, rule.TTL > 0
+		}
+	}
+
+	return 0, false
+}
+
+func (e *Entry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(e.StatusCode),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// revalidate returns the copy of the request with the conditional headers taken from the entry.
+func revalidate(req *http.Request, entry *Entry) *http.Request {
+	etag := entry.Header.Get("ETag")
+	lastModified := entry.Header.Get("Last-Modified")
+
+	if etag == "" && lastModified == "" {
+		return req
+	}
+
+	req = req.Clone(req.Context())
+
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	return req
+}
+
+// cacheKey returns the method and URL of the request. The host is included so that the clients of the different spaces can share the store. The API key is removed so that it is never written to the store.
+func cacheKey(req *http.Request) string {
+	query := req.URL.Query()
+	query.Del("apiKey")
+
+	return req.Method + " " + req.URL.Scheme + "://" + req.URL.Host + req.URL.Path + "?" + query.Encode()
+}
@@ -0,0 +1,164 @@
+package cache
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	backlog "github.com/moutend/go-backlog"
+)
+
+type fakeServer struct {
+	calls       int
+	conditional int
+}
+
+func (f *fakeServer) Do(req *http.Request) (*http.Response, error) {
+	f.calls++
+
+	if req.Header.Get("If-None-Match") == `"v1"` {
+		f.conditional++
+		return &http.Response{StatusCode: http.StatusNotModified, Header: http.Header{}, Body: http.NoBody}, nil
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Etag": []string{`"v1"`}},
+		Body:       ioutil.NopCloser(strings.NewReader(`[{"id":1,"name":"Open"}]`)),
+	}, nil
+}
+
+func newClient(t *testing.T, store Store) (*backlog.Client, *fakeServer, *time.Time) {
+	now := time.Date(2017, 8, 8, 0, 0, 0, 0, time.UTC)
+	server := &fakeServer{}
+
+	c := New(store, DefaultRules...)
+	c.now = func() time.Time { return now }
+
+	client, err := backlog.New("spaceName", "XXXXXXXX")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client.SetHTTPClient(server)
+	client.Use(c.Middleware())
+
+	return client, server, &now
+}
+
+func TestCache(t *testing.T) {
+	store := NewMemoryStore()
+	client, server, now := newClient(t, store)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetStatuses(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if server.calls != 1 {
+		t.Fatalf("expected 1 call, got %d", server.calls)
+	}
+
+	// The expired entry is revalidated with ETag.
+	*now = now.Add(2 * time.Hour)
+
+	entry, _ := store.Get("statuses", "GET https://spaceName.backlog.jp/api/v2/statuses?")
+	stored := entry.Stored
+
+	if _, err := client.GetStatuses(); err != nil {
+		t.Fatal(err)
+	}
+	if server.conditional != 1 {
+		t.Fatalf("expected 1 conditional request, got %d", server.conditional)
+	}
+	if !entry.Stored.Equal(stored) {
+		t.Fatal("the entry shared with the store is modified")
+	}
+	if refreshed, _ := store.Get("statuses", "GET https://spaceName.backlog.jp/api/v2/statuses?"); !refreshed.Stored.Equal(*now) {
+		t.Fatalf("the entry is not refreshed: %v", refreshed.Stored)
+	}
+
+	// Requests which do not match any rule are not cached.
+	for i := 0; i < 2; i++ {
+		client.GetIssues(nil)
+	}
+	if server.calls != 4 {
+		t.Fatalf("expected 4 calls, got %d", server.calls)
+	}
+}
+
+func TestCacheInvalidation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	client, server, _ := newClient(t, NewDiskStore(dir))
+
+	client.GetProjects(nil)
+	client.GetProjects(nil)
+
+	if server.calls != 1 {
+		t.Fatalf("expected 1 call, got %d", server.calls)
+	}
+
+	// The write to the other family keeps the entry.
+	client.AddStar(backlog.StarTargetIssue, 1)
+	client.GetProjects(nil)
+
+	if server.calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", server.calls)
+	}
+
+	// The write to the same family purges the entry. The fake response is not a webhook, so the error is ignored.
+	client.AddWebhook("SAMPLE", &backlog.Webhook{Name: "deploy"})
+	client.GetProjects(nil)
+
+	if server.calls != 4 {
+		t.Fatalf("expected 4 calls, got %d", server.calls)
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	req, _ := http.NewRequest("GET", "https://example.backlog.jp/api/v2/statuses?apiKey=secret&count=1", nil)
+
+	if key := cacheKey(req); strings.Contains(key, "secret") {
+		t.Fatalf("API key is in the cache key %v", key)
+	}
+	if rel := backlog.RelativePath(req.URL.Path); rel != "statuses" {
+		t.Fatalf("expected statuses, got %v", rel)
+	}
+}
+
+func TestCacheSharedBetweenSpaces(t *testing.T) {
+	store := NewMemoryStore()
+	c := New(store, DefaultRules...)
+
+	for _, space := range []string{"alpha", "beta"} {
+		client, err := backlog.New(space, "XXXXXXXX")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		client.SetHTTPClient(backlog.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(strings.NewReader(`[{"id":1,"name":"` + req.URL.Host + `"}]`)),
+			}, nil
+		}))
+		client.Use(c.Middleware())
+
+		statuses, err := client.GetStatuses()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := space + ".backlog.jp"; statuses[0].Name != expected {
+			t.Fatalf("expected: %s actual: %s", expected, statuses[0].Name)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Entry represents the cached response.
+type Entry struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	Stored     time.Time   `json:"stored"`
+}
+
+// Store saves the entries grouped by the resource family, such as "issues" or "projects", so that all the entries of the family can be purged at once.
+type Store interface {
+	Get(family, key string) (*Entry, bool)
+	Set(family, key string, entry *Entry)
+	Purge(family string)
+}
+
+type memoryStore struct {
+	mu       sync.Mutex
+	families map[string]map[string]*Entry
+}
+
+// NewMemoryStore returns the Store which keeps the entries in memory.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		families: map[string]map[string]*Entry{},
+	}
+}
+
+func (s *memoryStore) Get(family, key string) (*Entry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.families[family][key]
+
+	return entry, ok
+}
+
+func (s *memoryStore) Set(family, key string, entry *Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.families[family] == nil {
+		s.families[family] = map[string]*Entry{}
+	}
+
+	s.families[family][key] = entry
+}
+
+func (s *memoryStore) Purge(family string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.families, family)
+}
+
+type diskStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewDiskStore returns the Store which writes the entries under dir. Each family is stored in its own directory. The errors are ignored, since the cache can always be refetched.
+func NewDiskStore(dir string) Store {
+	return &diskStore{
+		dir: dir,
+	}
+}
+
+func (s *diskStore) Get(family, key string) (*Entry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := ioutil.ReadFile(s.path(family, key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry Entry
+
+	if err = json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+
+	return &entry, true
+}
+
+func (s *diskStore) Set(family, key string, entry *Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	path := s.path(family, key)
+
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+
+	ioutil.WriteFile(path, data, 0600)
+}
+
+func (s *diskStore) Purge(family string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	os.RemoveAll(filepath.Join(s.dir, hash(family)))
+}
+
+func (s *diskStore) path(family, key string) string {
+	return filepath.Join(s.dir, hash(family), hash(key)+".json")
+}
+
+func hash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+
+	return hex.EncodeToString(sum[:])
+}
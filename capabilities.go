@@ -0,0 +1,38 @@
+package backlog
+
+// Capabilities represents the optional features enabled for the space or the project.
+type Capabilities struct {
+	Git            bool
+	Subversion     bool
+	Wiki           bool
+	WikiAttachment bool
+	FileSharing    bool
+	Subtasking     bool
+	Gantt          bool
+	Burndown       bool
+}
+
+func newCapabilities(licence *Licence) Capabilities {
+	return Capabilities{
+		Git:            licence.Git,
+		Subversion:     licence.Subversion,
+		Wiki:           true,
+		WikiAttachment: licence.WikiAttachment,
+		FileSharing:    licence.FileSharing,
+		Subtasking:     licence.ParentChild,
+		Gantt:          licence.Gantt,
+		Burndown:       licence.Burndown,
+	}
+}
+
+// ForProject returns the capabilities narrowed down by the settings of the project.
+func (c Capabilities) ForProject(project *Project) Capabilities {
+	c.Wiki = c.Wiki && project.UseWiki
+	c.WikiAttachment = c.WikiAttachment && project.UseWiki
+	c.FileSharing = c.FileSharing && project.UseFileSharing
+	c.Subtasking = c.Subtasking && project.SubtaskingEnabled
+	c.Gantt = c.Gantt && project.ChartEnabled
+	c.Burndown = c.Burndown && project.ChartEnabled
+
+	return c
+}
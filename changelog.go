@@ -1,10 +1,48 @@
 package backlog
 
+// ChangeLogField represents the identifier of the field changed by a comment.
+type ChangeLogField string
+
+const (
+	ChangeLogFieldSummary        ChangeLogField = "summary"
+	ChangeLogFieldDescription    ChangeLogField = "description"
+	ChangeLogFieldCategory       ChangeLogField = "component"
+	ChangeLogFieldVersion        ChangeLogField = "version"
+	ChangeLogFieldMilestone      ChangeLogField = "milestone"
+	ChangeLogFieldStatus         ChangeLogField = "status"
+	ChangeLogFieldAssigner       ChangeLogField = "assigner"
+	ChangeLogFieldIssueType      ChangeLogField = "issueType"
+	ChangeLogFieldStartDate      ChangeLogField = "startDate"
+	ChangeLogFieldDueDate        ChangeLogField = "limitDate"
+	ChangeLogFieldPriority       ChangeLogField = "priority"
+	ChangeLogFieldResolution     ChangeLogField = "resolution"
+	ChangeLogFieldEstimatedHours ChangeLogField = "estimatedHours"
+	ChangeLogFieldActualHours    ChangeLogField = "actualHours"
+	ChangeLogFieldParentIssue    ChangeLogField = "parentIssue"
+	ChangeLogFieldAttachment     ChangeLogField = "attachment"
+	ChangeLogFieldSharedFile     ChangeLogField = "shared_file"
+	ChangeLogFieldNotification   ChangeLogField = "notification"
+)
+
 type ChangeLog struct {
-	Field            string           `json:"field"`
+	Field            ChangeLogField   `json:"field"`
 	NewValue         string           `json:"newValue"`
 	OriginalValue    string           `json:"originalValue"`
 	AttachmentInfo   AttachmentInfo   `json:"attachmentInfo"`
-	AttributeInfo    string           `json:"attributeInfo"`
+	AttributeInfo    *AttributeInfo   `json:"attributeInfo"`
 	NotificationInfo NotificationInfo `json:"notificationInfo"`
 }
+
+// IsCustomField reports whether the change log refers to a custom field.
+func (c ChangeLog) IsCustomField() bool {
+	return c.AttributeInfo != nil
+}
+
+// CustomFieldId returns the id of the changed custom field, or 0 if the change log does not refer to a custom field.
+func (c ChangeLog) CustomFieldId() int {
+	if c.AttributeInfo == nil {
+		return 0
+	}
+
+	return c.AttributeInfo.Id
+}
@@ -8,14 +8,22 @@ import (
 	"io"
 	"io/ioutil"
 	"log"
+	"log/slog"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"strconv"
+	"time"
 )
 
 type Client struct {
-	token  string
-	root   *url.URL
-	logger *log.Logger
+	token       string
+	root        *url.URL
+	logger      *slog.Logger
+	logOptions  LogOptions
+	httpClient  Doer
+	middlewares []Middleware
+	dryRun      dryRun
 }
 
 func New(spaceName, token string) (*Client, error) {
@@ -29,19 +37,18 @@ func New(spaceName, token string) (*Client, error) {
 		return nil, err
 	}
 
-	logger := log.New(ioutil.Discard, "", log.LstdFlags)
+	logger := slog.New(slog.NewTextHandler(ioutil.Discard, nil))
 	client := &Client{
-		token,
-		root,
-		logger,
+		token:      token,
+		root:       root,
+		logger:     logger,
+		httpClient: &http.Client{},
 	}
 
 	return client, nil
 }
 
-func (c *Client) doContext(ctx context.Context, method string, endpoint *url.URL, query url.Values, payload io.Reader) (response []byte, err error) {
-	c.logger.Println(method, endpoint)
-
+func (c *Client) doContext(ctx context.Context, method string, endpoint *url.URL, query url.Values, contentType string, payload io.Reader) (response []byte, err error) {
 	if query == nil {
 		query = url.Values{}
 	}
@@ -50,33 +57,26 @@ func (c *Client) doContext(ctx context.Context, method string, endpoint *url.URL
 	query.Add("apiKey", c.token)
 	endpoint.RawQuery = query.Encode()
 
-	c.logger.Println("query", endpoint.RawQuery)
-	c.logger.Println("payload", payload)
-
 	req, err := http.NewRequest(method, endpoint.String(), payload)
 	if err != nil {
 		return nil, err
 	}
 
-	httpClient := &http.Client{}
 	req = req.WithContext(ctx)
 
-	if method == "POST" || method == "PATCH" {
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
 	}
 
-	res, err := httpClient.Do(req)
+	res, err := c.doer().Do(req)
 	if err != nil {
-		return nil, err
+		return nil, redactError(err)
 	}
 	defer res.Body.Close()
 
 	if response, err = ioutil.ReadAll(res.Body); err != nil {
 		return nil, err
 	}
-
-	c.logger.Println(string(response[:]))
-
 	if res.StatusCode >= 200 && res.StatusCode < 300 {
 		return response, nil
 	}
@@ -94,24 +94,28 @@ func (c *Client) doContext(ctx context.Context, method string, endpoint *url.URL
 }
 
 func (c *Client) getContext(ctx context.Context, endpoint *url.URL, query url.Values) (response []byte, err error) {
-	return c.doContext(ctx, "GET", endpoint, query, nil)
+	return c.doContext(ctx, "GET", endpoint, query, "", nil)
 }
 
 func (c *Client) patchContext(ctx context.Context, endpoint *url.URL, query url.Values, payload io.Reader) (response []byte, err error) {
-	return c.doContext(ctx, "PATCH", endpoint, query, payload)
+	return c.doContext(ctx, "PATCH", endpoint, query, "application/x-www-form-urlencoded", payload)
 }
 
 func (c *Client) postContext(ctx context.Context, endpoint *url.URL, query url.Values, payload io.Reader) (response []byte, err error) {
-	return c.doContext(ctx, "POST", endpoint, query, payload)
+	return c.doContext(ctx, "POST", endpoint, query, "application/x-www-form-urlencoded", payload)
+}
+
+func (c *Client) postMultipartContext(ctx context.Context, endpoint *url.URL, query url.Values, contentType string, payload io.Reader) (response []byte, err error) {
+	return c.doContext(ctx, "POST", endpoint, query, contentType, payload)
 }
 
 func (c *Client) deleteContext(ctx context.Context, endpoint *url.URL, query url.Values) (response []byte, err error) {
-	return c.doContext(ctx, "DELETE", endpoint, query, nil)
+	return c.doContext(ctx, "DELETE", endpoint, query, "", nil)
 }
 
+// SetLogger sends the log to the standard logger. Each record is written with logger.Output, so the prefix and flags of the logger are kept. Use SetLogHandler for the structured log.
 func (c *Client) SetLogger(logger *log.Logger) {
-	c.logger = logger
-	c.logger.Println("set logger")
+	c.SetLogHandler(&stdLogHandler{logger: logger}, nil)
 
 	return
 }
@@ -121,6 +125,8 @@ func (c *Client) GetProjects(query url.Values) ([]*Project, error) {
 }
 
 func (c *Client) GetProjectsContext(ctx context.Context, query url.Values) ([]*Project, error) {
+	ctx = withOperation(ctx, "GetProjects")
+
 	var err error
 	var response []byte
 	var projects []*Project
@@ -147,6 +153,8 @@ func (c *Client) GetIssues(query url.Values) ([]*Issue, error) {
 }
 
 func (c *Client) GetIssuesContext(ctx context.Context, query url.Values) ([]*Issue, error) {
+	ctx = withOperation(ctx, "GetIssues")
+
 	var err error
 	var response []byte
 	var issues []*Issue
@@ -173,6 +181,8 @@ func (c *Client) GetIssue(issueId string) (*Issue, error) {
 }
 
 func (c *Client) GetIssueContext(ctx context.Context, issueId string) (*Issue, error) {
+	ctx = withOperation(ctx, "GetIssue")
+
 	var err error
 	var response []byte
 	var issue Issue
@@ -196,6 +206,8 @@ func (c *Client) DeleteIssue(issueId int) (*Issue, error) {
 }
 
 func (c *Client) DeleteIssueContext(ctx context.Context, issueId int) (*Issue, error) {
+	ctx = withOperation(ctx, "DeleteIssue")
+
 	var err error
 	var response []byte
 	var issue Issue
@@ -219,6 +231,8 @@ func (c *Client) CreateIssue(values url.Values) (*Issue, error) {
 }
 
 func (c *Client) CreateIssueContext(ctx context.Context, values url.Values) (*Issue, error) {
+	ctx = withOperation(ctx, "CreateIssue")
+
 	var err error
 	var response []byte
 	var issue Issue
@@ -228,13 +242,13 @@ func (c *Client) CreateIssueContext(ctx context.Context, values url.Values) (*Is
 	payload := bytes.NewBufferString(values.Encode())
 
 	if path, err = c.root.Parse("./issues"); err != nil {
-		return nil, fmt.Errorf("%s: %s", errorPrefix, err)
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
 	}
 	if response, err = c.postContext(ctx, path, nil, payload); err != nil {
-		return nil, fmt.Errorf("%s: %s", errorPrefix, err)
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
 	}
 	if err = json.Unmarshal(response, &issue); err != nil {
-		return nil, fmt.Errorf("%s: %s", errorPrefix, err)
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
 	}
 
 	return &issue, nil
@@ -245,6 +259,8 @@ func (c *Client) SetIssue(issueId string, values url.Values) (*Issue, error) {
 }
 
 func (c *Client) SetIssueContext(ctx context.Context, issueId string, values url.Values) (*Issue, error) {
+	ctx = withOperation(ctx, "SetIssue")
+
 	var err error
 	var response []byte
 	var issue Issue
@@ -270,6 +286,8 @@ func (c *Client) GetIssuesCount(query url.Values) (int, error) {
 }
 
 func (c *Client) GetIssuesCountContext(ctx context.Context, query url.Values) (int, error) {
+	ctx = withOperation(ctx, "GetIssuesCount")
+
 	var err error
 	var response []byte
 	var count struct {
@@ -295,6 +313,8 @@ func (c *Client) GetStatuses() ([]*Status, error) {
 }
 
 func (c *Client) GetStatusesContext(ctx context.Context) ([]*Status, error) {
+	ctx = withOperation(ctx, "GetStatuses")
+
 	var err error
 	var response []byte
 	var statuses []*Status
@@ -318,6 +338,8 @@ func (c *Client) GetIssueTypes(projectId int) ([]*IssueType, error) {
 }
 
 func (c *Client) GetIssueTypesContext(ctx context.Context, projectId int) ([]*IssueType, error) {
+	ctx = withOperation(ctx, "GetIssueTypes")
+
 	var err error
 	var response []byte
 	var issueTypes []*IssueType
@@ -341,6 +363,8 @@ func (c *Client) GetPriorities() ([]*Priority, error) {
 }
 
 func (c *Client) GetPrioritiesContext(ctx context.Context) ([]*Priority, error) {
+	ctx = withOperation(ctx, "GetPriorities")
+
 	var err error
 	var response []byte
 	var priorities []*Priority
@@ -364,6 +388,8 @@ func (c *Client) GetMyself() (*User, error) {
 }
 
 func (c *Client) GetMyselfContext(ctx context.Context) (*User, error) {
+	ctx = withOperation(ctx, "GetMyself")
+
 	path, err := c.root.Parse("./users/myself")
 	if err != nil {
 		return nil, err
@@ -384,6 +410,8 @@ func (c *Client) GetComments(issueId string, values url.Values) ([]*Comment, err
 }
 
 func (c *Client) GetCommentsContext(ctx context.Context, issueId string, values url.Values) ([]*Comment, error) {
+	ctx = withOperation(ctx, "GetComments")
+
 	path, err := c.root.Parse(fmt.Sprintf("./issues/%v/comments", issueId))
 	if err != nil {
 		return nil, err
@@ -400,11 +428,270 @@ func (c *Client) GetCommentsContext(ctx context.Context, issueId string, values
 	return comments, err
 }
 
+func (c *Client) AddComment(issueId, content string, notifiedUserIds, attachmentIds []int) (*Comment, error) {
+	return c.AddCommentContext(context.Background(), issueId, content, notifiedUserIds, attachmentIds)
+}
+
+func (c *Client) AddCommentContext(ctx context.Context, issueId, content string, notifiedUserIds, attachmentIds []int) (*Comment, error) {
+	ctx = withOperation(ctx, "AddComment")
+
+	var err error
+	var response []byte
+	var comment Comment
+	var path *url.URL
+
+	errorPrefix := "AddCommentContext"
+	values := url.Values{}
+	values.Set("content", content)
+
+	for _, id := range notifiedUserIds {
+		values.Add("notifiedUserId[]", strconv.Itoa(id))
+	}
+	for _, id := range attachmentIds {
+		values.Add("attachmentId[]", strconv.Itoa(id))
+	}
+
+	payload := bytes.NewBufferString(values.Encode())
+
+	if path, err = c.root.Parse(fmt.Sprintf("./issues/%v/comments", issueId)); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if response, err = c.postContext(ctx, path, nil, payload); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if err = json.Unmarshal(response, &comment); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+
+	return &comment, nil
+}
+
+func (c *Client) GetCommentsCount(issueId string) (int, error) {
+	return c.GetCommentsCountContext(context.Background(), issueId)
+}
+
+func (c *Client) GetCommentsCountContext(ctx context.Context, issueId string) (int, error) {
+	ctx = withOperation(ctx, "GetCommentsCount")
+
+	var err error
+	var response []byte
+	var count struct {
+		Count int `json:"count"`
+	}
+	var path *url.URL
+
+	if path, err = c.root.Parse(fmt.Sprintf("./issues/%v/comments/count", issueId)); err != nil {
+		return 0, err
+	}
+	if response, err = c.getContext(ctx, path, nil); err != nil {
+		return 0, err
+	}
+	if err = json.Unmarshal(response, &count); err != nil {
+		return 0, err
+	}
+
+	return count.Count, nil
+}
+
+func (c *Client) GetComment(issueId string, commentId int) (*Comment, error) {
+	return c.GetCommentContext(context.Background(), issueId, commentId)
+}
+
+func (c *Client) GetCommentContext(ctx context.Context, issueId string, commentId int) (*Comment, error) {
+	ctx = withOperation(ctx, "GetComment")
+
+	var err error
+	var response []byte
+	var comment Comment
+	var path *url.URL
+
+	if path, err = c.root.Parse(fmt.Sprintf("./issues/%v/comments/%v", issueId, commentId)); err != nil {
+		return nil, err
+	}
+	if response, err = c.getContext(ctx, path, nil); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &comment); err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+func (c *Client) UpdateComment(issueId string, commentId int, content string) (*Comment, error) {
+	return c.UpdateCommentContext(context.Background(), issueId, commentId, content)
+}
+
+func (c *Client) UpdateCommentContext(ctx context.Context, issueId string, commentId int, content string) (*Comment, error) {
+	ctx = withOperation(ctx, "UpdateComment")
+
+	var err error
+	var response []byte
+	var comment Comment
+	var path *url.URL
+
+	errorPrefix := "UpdateCommentContext"
+	values := url.Values{}
+	values.Set("content", content)
+	payload := bytes.NewBufferString(values.Encode())
+
+	if path, err = c.root.Parse(fmt.Sprintf("./issues/%v/comments/%v", issueId, commentId)); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if response, err = c.patchContext(ctx, path, nil, payload); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if err = json.Unmarshal(response, &comment); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+
+	return &comment, nil
+}
+
+func (c *Client) DeleteComment(issueId string, commentId int) (*Comment, error) {
+	return c.DeleteCommentContext(context.Background(), issueId, commentId)
+}
+
+func (c *Client) DeleteCommentContext(ctx context.Context, issueId string, commentId int) (*Comment, error) {
+	ctx = withOperation(ctx, "DeleteComment")
+
+	var err error
+	var response []byte
+	var comment Comment
+	var path *url.URL
+
+	if path, err = c.root.Parse(fmt.Sprintf("./issues/%v/comments/%v", issueId, commentId)); err != nil {
+		return nil, err
+	}
+	if response, err = c.deleteContext(ctx, path, nil); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &comment); err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+func (c *Client) GetCommentNotifications(issueId string, commentId int) ([]*Notification, error) {
+	return c.GetCommentNotificationsContext(context.Background(), issueId, commentId)
+}
+
+func (c *Client) GetCommentNotificationsContext(ctx context.Context, issueId string, commentId int) ([]*Notification, error) {
+	ctx = withOperation(ctx, "GetCommentNotifications")
+
+	var err error
+	var response []byte
+	var notifications []*Notification
+	var path *url.URL
+
+	if path, err = c.root.Parse(fmt.Sprintf("./issues/%v/comments/%v/notifications", issueId, commentId)); err != nil {
+		return nil, err
+	}
+	if response, err = c.getContext(ctx, path, nil); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &notifications); err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (c *Client) AddCommentNotification(issueId string, commentId int, notifiedUserIds []int) (*Comment, error) {
+	return c.AddCommentNotificationContext(context.Background(), issueId, commentId, notifiedUserIds)
+}
+
+func (c *Client) AddCommentNotificationContext(ctx context.Context, issueId string, commentId int, notifiedUserIds []int) (*Comment, error) {
+	ctx = withOperation(ctx, "AddCommentNotification")
+
+	var err error
+	var response []byte
+	var comment Comment
+	var path *url.URL
+
+	errorPrefix := "AddCommentNotificationContext"
+	values := url.Values{}
+
+	for _, id := range notifiedUserIds {
+		values.Add("notifiedUserId[]", strconv.Itoa(id))
+	}
+
+	payload := bytes.NewBufferString(values.Encode())
+
+	if path, err = c.root.Parse(fmt.Sprintf("./issues/%v/comments/%v/notifications", issueId, commentId)); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if response, err = c.postContext(ctx, path, nil, payload); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if err = json.Unmarshal(response, &comment); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+
+	return &comment, nil
+}
+
+func (c *Client) GetIssueHistory(issueId string) (*IssueHistory, error) {
+	return c.GetIssueHistoryContext(context.Background(), issueId)
+}
+
+// GetIssueHistoryContext fetches the issue and all of its comments, then returns the changes recorded in the comments in chronological order.
+func (c *Client) GetIssueHistoryContext(ctx context.Context, issueId string) (*IssueHistory, error) {
+	ctx = withOperation(ctx, "GetIssueHistory")
+
+	var err error
+	var issue *Issue
+	var comments []*Comment
+
+	errorPrefix := "GetIssueHistoryContext"
+
+	if issue, err = c.GetIssueContext(ctx, issueId); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+
+	// The maximum number of comments per request is 100.
+	const count = 100
+	minId := 0
+
+	for {
+		query := url.Values{}
+		query.Set("count", strconv.Itoa(count))
+		query.Set("order", "asc")
+
+		if minId > 0 {
+			query.Set("minId", strconv.Itoa(minId))
+		}
+
+		page, err := c.GetCommentsContext(ctx, issueId, query)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+		}
+		added := 0
+
+		for _, comment := range page {
+			if comment.Id > minId {
+				comments = append(comments, comment)
+				minId = comment.Id
+				added++
+			}
+		}
+		if len(page) < count || added == 0 {
+			break
+		}
+	}
+
+	return newIssueHistory(issue, comments), nil
+}
+
 func (c *Client) GetPullRequests(projectID, repositoryID string, query url.Values) ([]*PullRequest, error) {
 	return c.GetPullRequestsContext(context.Background(), projectID, repositoryID, query)
 }
 
+// GetPullRequestsContext returns the pull requests in the repository. The query can be built with PullRequestQuery.
 func (c *Client) GetPullRequestsContext(ctx context.Context, projectID, repositoryID string, query url.Values) ([]*PullRequest, error) {
+	ctx = withOperation(ctx, "GetPullRequests")
+
 	var err error
 	var response []byte
 	var pullRequests []*PullRequest
@@ -431,6 +718,8 @@ func (c *Client) GetPullRequest(projectID, repositoryID string, number int, quer
 }
 
 func (c *Client) GetPullRequestContext(ctx context.Context, projectID, repositoryID string, number int, query url.Values) (*PullRequest, error) {
+	ctx = withOperation(ctx, "GetPullRequest")
+
 	var err error
 	var response []byte
 	var pullRequest *PullRequest
@@ -457,6 +746,8 @@ func (c *Client) GetPullRequestsCount(projectID, repositoryID string, query url.
 }
 
 func (c *Client) GetPullRequestsCountContext(ctx context.Context, projectID, repositoryID string, query url.Values) (int, error) {
+	ctx = withOperation(ctx, "GetPullRequestsCount")
+
 	var err error
 	var response []byte
 	var path *url.URL
@@ -485,6 +776,8 @@ func (c *Client) GetRepositories(projectId string, query url.Values) ([]*Reposit
 }
 
 func (c *Client) GetRepositoriesContext(ctx context.Context, projectId string, query url.Values) ([]*Repository, error) {
+	ctx = withOperation(ctx, "GetRepositories")
+
 	var err error
 	var response []byte
 	var repositories []*Repository
@@ -506,11 +799,40 @@ func (c *Client) GetRepositoriesContext(ctx context.Context, projectId string, q
 	return repositories, nil
 }
 
+func (c *Client) GetRepository(projectId, repositoryId string) (*Repository, error) {
+	return c.GetRepositoryContext(context.Background(), projectId, repositoryId)
+}
+
+// GetRepositoryContext returns the repository. The repositoryId can be either the id or the name of the repository.
+func (c *Client) GetRepositoryContext(ctx context.Context, projectId, repositoryId string) (*Repository, error) {
+	ctx = withOperation(ctx, "GetRepository")
+
+	var err error
+	var response []byte
+	var repository Repository
+	var path *url.URL
+
+	if path, err = c.root.Parse(fmt.Sprintf("./projects/%v/git/repositories/%v", projectId, repositoryId)); err != nil {
+		return nil, err
+	}
+	if response, err = c.getContext(ctx, path, nil); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &repository); err != nil {
+		return nil, err
+	}
+
+	return &repository, nil
+}
+
 func (c *Client) CreatePullRequest(projectId, repositoryId string, values url.Values) (*PullRequest, error) {
 	return c.CreatePullRequestContext(context.Background(), projectId, repositoryId, values)
 }
 
+// CreatePullRequestContext creates the pull request. The values can be built with CreatePullRequestInput. To attach files, upload them with UploadAttachment and add their ids to the values as attachmentId[].
 func (c *Client) CreatePullRequestContext(ctx context.Context, projectId, repositoryId string, values url.Values) (*PullRequest, error) {
+	ctx = withOperation(ctx, "CreatePullRequest")
+
 	var err error
 	var response []byte
 	var pullRequest PullRequest
@@ -520,13 +842,13 @@ func (c *Client) CreatePullRequestContext(ctx context.Context, projectId, reposi
 	payload := bytes.NewBufferString(values.Encode())
 
 	if path, err = c.root.Parse(fmt.Sprintf("./projects/%v/git/repositories/%v/pullRequests", projectId, repositoryId)); err != nil {
-		return nil, fmt.Errorf("%s: %s", errorPrefix, err)
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
 	}
 	if response, err = c.postContext(ctx, path, nil, payload); err != nil {
-		return nil, fmt.Errorf("%s: %s", errorPrefix, err)
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
 	}
 	if err = json.Unmarshal(response, &pullRequest); err != nil {
-		return nil, fmt.Errorf("%s: %s", errorPrefix, err)
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
 	}
 
 	return &pullRequest, nil
@@ -536,7 +858,10 @@ func (c *Client) UpdatePullRequest(projectId, repositoryId string, number int, v
 	return c.UpdatePullRequestContext(context.Background(), projectId, repositoryId, number, values)
 }
 
+// UpdatePullRequestContext updates the pull request. The values can be built with UpdatePullRequestInput. To attach files, upload them with UploadAttachment and add their ids to the values as attachmentId[].
 func (c *Client) UpdatePullRequestContext(ctx context.Context, projectId, repositoryId string, number int, values url.Values) (*PullRequest, error) {
+	ctx = withOperation(ctx, "UpdatePullRequest")
+
 	var err error
 	var response []byte
 	var pullRequest PullRequest
@@ -545,14 +870,14 @@ func (c *Client) UpdatePullRequestContext(ctx context.Context, projectId, reposi
 	errorPrefix := "UpdatePullRequestContext"
 	payload := bytes.NewBufferString(values.Encode())
 
-	if path, err = c.root.Parse(fmt.Sprintf("./projects/%v/git/repositories/%v/pullRequests", projectId, repositoryId)); err != nil {
-		return nil, fmt.Errorf("%s: %s", errorPrefix, err)
+	if path, err = c.root.Parse(fmt.Sprintf("./projects/%v/git/repositories/%v/pullRequests/%v", projectId, repositoryId, number)); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
 	}
 	if response, err = c.patchContext(ctx, path, nil, payload); err != nil {
-		return nil, fmt.Errorf("%s: %s", errorPrefix, err)
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
 	}
 	if err = json.Unmarshal(response, &pullRequest); err != nil {
-		return nil, fmt.Errorf("%s: %s", errorPrefix, err)
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
 	}
 
 	return &pullRequest, nil
@@ -563,6 +888,8 @@ func (c *Client) GetUsers() ([]*User, error) {
 }
 
 func (c *Client) GetUsersContext(ctx context.Context) ([]*User, error) {
+	ctx = withOperation(ctx, "GetUsers")
+
 	var err error
 	var response []byte
 	var users []*User
@@ -580,3 +907,1301 @@ func (c *Client) GetUsersContext(ctx context.Context) ([]*User, error) {
 
 	return users, nil
 }
+
+func (c *Client) GetWikis(projectId string, query url.Values) ([]*Wiki, error) {
+	return c.GetWikisContext(context.Background(), projectId, query)
+}
+
+func (c *Client) GetWikisContext(ctx context.Context, projectId string, query url.Values) ([]*Wiki, error) {
+	ctx = withOperation(ctx, "GetWikis")
+
+	var err error
+	var response []byte
+	var wikis []*Wiki
+	var path *url.URL
+
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("projectIdOrKey", projectId)
+
+	if path, err = c.root.Parse("./wikis"); err != nil {
+		return nil, err
+	}
+	if response, err = c.getContext(ctx, path, query); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &wikis); err != nil {
+		return nil, err
+	}
+
+	return wikis, nil
+}
+
+func (c *Client) GetWikisCount(projectId string) (int, error) {
+	return c.GetWikisCountContext(context.Background(), projectId)
+}
+
+func (c *Client) GetWikisCountContext(ctx context.Context, projectId string) (int, error) {
+	ctx = withOperation(ctx, "GetWikisCount")
+
+	var err error
+	var response []byte
+	var count struct {
+		Count int `json:"count"`
+	}
+	var path *url.URL
+
+	query := url.Values{}
+	query.Set("projectIdOrKey", projectId)
+
+	if path, err = c.root.Parse("./wikis/count"); err != nil {
+		return 0, err
+	}
+	if response, err = c.getContext(ctx, path, query); err != nil {
+		return 0, err
+	}
+	if err = json.Unmarshal(response, &count); err != nil {
+		return 0, err
+	}
+
+	return count.Count, nil
+}
+
+func (c *Client) GetWikiTags(projectId string) ([]*WikiTag, error) {
+	return c.GetWikiTagsContext(context.Background(), projectId)
+}
+
+func (c *Client) GetWikiTagsContext(ctx context.Context, projectId string) ([]*WikiTag, error) {
+	ctx = withOperation(ctx, "GetWikiTags")
+
+	var err error
+	var response []byte
+	var tags []*WikiTag
+	var path *url.URL
+
+	query := url.Values{}
+	query.Set("projectIdOrKey", projectId)
+
+	if path, err = c.root.Parse("./wikis/tags"); err != nil {
+		return nil, err
+	}
+	if response, err = c.getContext(ctx, path, query); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &tags); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (c *Client) AddWiki(projectId int, name, content string, mailNotify bool) (*Wiki, error) {
+	return c.AddWikiContext(context.Background(), projectId, name, content, mailNotify)
+}
+
+// AddWikiContext creates the wiki page. The content must be written in the TextFormattingRule of the project.
+func (c *Client) AddWikiContext(ctx context.Context, projectId int, name, content string, mailNotify bool) (*Wiki, error) {
+	ctx = withOperation(ctx, "AddWiki")
+
+	var err error
+	var response []byte
+	var wiki Wiki
+	var path *url.URL
+
+	errorPrefix := "AddWikiContext"
+	values := url.Values{}
+	values.Set("projectId", strconv.Itoa(projectId))
+	values.Set("name", name)
+	values.Set("content", content)
+	values.Set("mailNotify", strconv.FormatBool(mailNotify))
+	payload := bytes.NewBufferString(values.Encode())
+
+	if path, err = c.root.Parse("./wikis"); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if response, err = c.postContext(ctx, path, nil, payload); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if err = json.Unmarshal(response, &wiki); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+
+	return &wiki, nil
+}
+
+func (c *Client) GetWiki(wikiId int) (*Wiki, error) {
+	return c.GetWikiContext(context.Background(), wikiId)
+}
+
+func (c *Client) GetWikiContext(ctx context.Context, wikiId int) (*Wiki, error) {
+	ctx = withOperation(ctx, "GetWiki")
+
+	var err error
+	var response []byte
+	var wiki Wiki
+	var path *url.URL
+
+	if path, err = c.root.Parse(fmt.Sprintf("./wikis/%v", wikiId)); err != nil {
+		return nil, err
+	}
+	if response, err = c.getContext(ctx, path, nil); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &wiki); err != nil {
+		return nil, err
+	}
+
+	return &wiki, nil
+}
+
+func (c *Client) UpdateWiki(wikiId int, name, content string, mailNotify bool) (*Wiki, error) {
+	return c.UpdateWikiContext(context.Background(), wikiId, name, content, mailNotify)
+}
+
+// UpdateWikiContext updates the wiki page. The empty name or content is left unchanged.
+func (c *Client) UpdateWikiContext(ctx context.Context, wikiId int, name, content string, mailNotify bool) (*Wiki, error) {
+	ctx = withOperation(ctx, "UpdateWiki")
+
+	var err error
+	var response []byte
+	var wiki Wiki
+	var path *url.URL
+
+	errorPrefix := "UpdateWikiContext"
+	values := url.Values{}
+
+	if name != "" {
+		values.Set("name", name)
+	}
+	if content != "" {
+		values.Set("content", content)
+	}
+
+	values.Set("mailNotify", strconv.FormatBool(mailNotify))
+	payload := bytes.NewBufferString(values.Encode())
+
+	if path, err = c.root.Parse(fmt.Sprintf("./wikis/%v", wikiId)); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if response, err = c.patchContext(ctx, path, nil, payload); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if err = json.Unmarshal(response, &wiki); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+
+	return &wiki, nil
+}
+
+func (c *Client) DeleteWiki(wikiId int, mailNotify bool) (*Wiki, error) {
+	return c.DeleteWikiContext(context.Background(), wikiId, mailNotify)
+}
+
+func (c *Client) DeleteWikiContext(ctx context.Context, wikiId int, mailNotify bool) (*Wiki, error) {
+	ctx = withOperation(ctx, "DeleteWiki")
+
+	var err error
+	var response []byte
+	var wiki Wiki
+	var path *url.URL
+
+	query := url.Values{}
+	query.Set("mailNotify", strconv.FormatBool(mailNotify))
+
+	if path, err = c.root.Parse(fmt.Sprintf("./wikis/%v", wikiId)); err != nil {
+		return nil, err
+	}
+	if response, err = c.deleteContext(ctx, path, query); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &wiki); err != nil {
+		return nil, err
+	}
+
+	return &wiki, nil
+}
+
+func (c *Client) GetWikiAttachments(wikiId int) ([]*Attachment, error) {
+	return c.GetWikiAttachmentsContext(context.Background(), wikiId)
+}
+
+func (c *Client) GetWikiAttachmentsContext(ctx context.Context, wikiId int) ([]*Attachment, error) {
+	ctx = withOperation(ctx, "GetWikiAttachments")
+
+	var err error
+	var response []byte
+	var attachments []*Attachment
+	var path *url.URL
+
+	if path, err = c.root.Parse(fmt.Sprintf("./wikis/%v/attachments", wikiId)); err != nil {
+		return nil, err
+	}
+	if response, err = c.getContext(ctx, path, nil); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &attachments); err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (c *Client) AddWikiAttachments(wikiId int, attachmentIds []int) ([]*Attachment, error) {
+	return c.AddWikiAttachmentsContext(context.Background(), wikiId, attachmentIds)
+}
+
+// AddWikiAttachmentsContext attaches the files uploaded to the space to the wiki page.
+func (c *Client) AddWikiAttachmentsContext(ctx context.Context, wikiId int, attachmentIds []int) ([]*Attachment, error) {
+	ctx = withOperation(ctx, "AddWikiAttachments")
+
+	var err error
+	var response []byte
+	var attachments []*Attachment
+	var path *url.URL
+
+	errorPrefix := "AddWikiAttachmentsContext"
+	values := url.Values{}
+
+	for _, id := range attachmentIds {
+		values.Add("attachmentId[]", strconv.Itoa(id))
+	}
+
+	payload := bytes.NewBufferString(values.Encode())
+
+	if path, err = c.root.Parse(fmt.Sprintf("./wikis/%v/attachments", wikiId)); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if response, err = c.postContext(ctx, path, nil, payload); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if err = json.Unmarshal(response, &attachments); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+
+	return attachments, nil
+}
+
+func (c *Client) DownloadWikiAttachment(wikiId, attachmentId int) ([]byte, error) {
+	return c.DownloadWikiAttachmentContext(context.Background(), wikiId, attachmentId)
+}
+
+func (c *Client) DownloadWikiAttachmentContext(ctx context.Context, wikiId, attachmentId int) ([]byte, error) {
+	ctx = withOperation(ctx, "DownloadWikiAttachment")
+
+	var err error
+	var path *url.URL
+
+	if path, err = c.root.Parse(fmt.Sprintf("./wikis/%v/attachments/%v", wikiId, attachmentId)); err != nil {
+		return nil, err
+	}
+
+	return c.getContext(ctx, path, nil)
+}
+
+func (c *Client) DeleteWikiAttachment(wikiId, attachmentId int) (*Attachment, error) {
+	return c.DeleteWikiAttachmentContext(context.Background(), wikiId, attachmentId)
+}
+
+func (c *Client) DeleteWikiAttachmentContext(ctx context.Context, wikiId, attachmentId int) (*Attachment, error) {
+	ctx = withOperation(ctx, "DeleteWikiAttachment")
+
+	var err error
+	var response []byte
+	var attachment Attachment
+	var path *url.URL
+
+	if path, err = c.root.Parse(fmt.Sprintf("./wikis/%v/attachments/%v", wikiId, attachmentId)); err != nil {
+		return nil, err
+	}
+	if response, err = c.deleteContext(ctx, path, nil); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &attachment); err != nil {
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+func (c *Client) GetWikiSharedFiles(wikiId int) ([]*SharedFile, error) {
+	return c.GetWikiSharedFilesContext(context.Background(), wikiId)
+}
+
+func (c *Client) GetWikiSharedFilesContext(ctx context.Context, wikiId int) ([]*SharedFile, error) {
+	ctx = withOperation(ctx, "GetWikiSharedFiles")
+
+	var err error
+	var response []byte
+	var sharedFiles []*SharedFile
+	var path *url.URL
+
+	if path, err = c.root.Parse(fmt.Sprintf("./wikis/%v/sharedFiles", wikiId)); err != nil {
+		return nil, err
+	}
+	if response, err = c.getContext(ctx, path, nil); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &sharedFiles); err != nil {
+		return nil, err
+	}
+
+	return sharedFiles, nil
+}
+
+func (c *Client) LinkWikiSharedFiles(wikiId int, fileIds []int) ([]*SharedFile, error) {
+	return c.LinkWikiSharedFilesContext(context.Background(), wikiId, fileIds)
+}
+
+func (c *Client) LinkWikiSharedFilesContext(ctx context.Context, wikiId int, fileIds []int) ([]*SharedFile, error) {
+	ctx = withOperation(ctx, "LinkWikiSharedFiles")
+
+	var err error
+	var response []byte
+	var sharedFiles []*SharedFile
+	var path *url.URL
+
+	errorPrefix := "LinkWikiSharedFilesContext"
+	values := url.Values{}
+
+	for _, id := range fileIds {
+		values.Add("fileId[]", strconv.Itoa(id))
+	}
+
+	payload := bytes.NewBufferString(values.Encode())
+
+	if path, err = c.root.Parse(fmt.Sprintf("./wikis/%v/sharedFiles", wikiId)); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if response, err = c.postContext(ctx, path, nil, payload); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if err = json.Unmarshal(response, &sharedFiles); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+
+	return sharedFiles, nil
+}
+
+func (c *Client) UnlinkWikiSharedFile(wikiId, sharedFileId int) (*SharedFile, error) {
+	return c.UnlinkWikiSharedFileContext(context.Background(), wikiId, sharedFileId)
+}
+
+func (c *Client) UnlinkWikiSharedFileContext(ctx context.Context, wikiId, sharedFileId int) (*SharedFile, error) {
+	ctx = withOperation(ctx, "UnlinkWikiSharedFile")
+
+	var err error
+	var response []byte
+	var sharedFile SharedFile
+	var path *url.URL
+
+	if path, err = c.root.Parse(fmt.Sprintf("./wikis/%v/sharedFiles/%v", wikiId, sharedFileId)); err != nil {
+		return nil, err
+	}
+	if response, err = c.deleteContext(ctx, path, nil); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &sharedFile); err != nil {
+		return nil, err
+	}
+
+	return &sharedFile, nil
+}
+
+func (c *Client) GetWikiHistory(wikiId int, query url.Values) ([]*WikiHistory, error) {
+	return c.GetWikiHistoryContext(context.Background(), wikiId, query)
+}
+
+// GetWikiHistoryContext returns the versions of the wiki page. The versions can be paged with minId, maxId, count and order in the query.
+func (c *Client) GetWikiHistoryContext(ctx context.Context, wikiId int, query url.Values) ([]*WikiHistory, error) {
+	ctx = withOperation(ctx, "GetWikiHistory")
+
+	var err error
+	var response []byte
+	var history []*WikiHistory
+	var path *url.URL
+
+	if query == nil {
+		query = url.Values{}
+	}
+	if path, err = c.root.Parse(fmt.Sprintf("./wikis/%v/history", wikiId)); err != nil {
+		return nil, err
+	}
+	if response, err = c.getContext(ctx, path, query); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &history); err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+func (c *Client) GetWikiStars(wikiId int) ([]*Star, error) {
+	return c.GetWikiStarsContext(context.Background(), wikiId)
+}
+
+func (c *Client) GetWikiStarsContext(ctx context.Context, wikiId int) ([]*Star, error) {
+	ctx = withOperation(ctx, "GetWikiStars")
+
+	var err error
+	var response []byte
+	var stars []*Star
+	var path *url.URL
+
+	if path, err = c.root.Parse(fmt.Sprintf("./wikis/%v/stars", wikiId)); err != nil {
+		return nil, err
+	}
+	if response, err = c.getContext(ctx, path, nil); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &stars); err != nil {
+		return nil, err
+	}
+
+	return stars, nil
+}
+
+func (c *Client) AddStar(target StarTarget, id int) error {
+	return c.AddStarContext(context.Background(), target, id)
+}
+
+func (c *Client) AddStarContext(ctx context.Context, target StarTarget, id int) error {
+	ctx = withOperation(ctx, "AddStar")
+
+	var err error
+	var path *url.URL
+
+	errorPrefix := "AddStarContext"
+	values := url.Values{}
+	values.Set(string(target), strconv.Itoa(id))
+	payload := bytes.NewBufferString(values.Encode())
+
+	if path, err = c.root.Parse("./stars"); err != nil {
+		return fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if _, err = c.postContext(ctx, path, nil, payload); err != nil {
+		return fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+
+	return nil
+}
+
+func (c *Client) RemoveStar(starId int) error {
+	return c.RemoveStarContext(context.Background(), starId)
+}
+
+func (c *Client) RemoveStarContext(ctx context.Context, starId int) error {
+	ctx = withOperation(ctx, "RemoveStar")
+
+	var err error
+	var path *url.URL
+
+	if path, err = c.root.Parse(fmt.Sprintf("./stars/%v", starId)); err != nil {
+		return err
+	}
+	if _, err = c.deleteContext(ctx, path, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) GetUserStars(userId int, query url.Values) ([]*Star, error) {
+	return c.GetUserStarsContext(context.Background(), userId, query)
+}
+
+// GetUserStarsContext returns the stars received by the user. The stars can be paged with minId, maxId, count and order in the query.
+func (c *Client) GetUserStarsContext(ctx context.Context, userId int, query url.Values) ([]*Star, error) {
+	ctx = withOperation(ctx, "GetUserStars")
+
+	var err error
+	var response []byte
+	var stars []*Star
+	var path *url.URL
+
+	if query == nil {
+		query = url.Values{}
+	}
+	if path, err = c.root.Parse(fmt.Sprintf("./users/%v/stars", userId)); err != nil {
+		return nil, err
+	}
+	if response, err = c.getContext(ctx, path, query); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &stars); err != nil {
+		return nil, err
+	}
+
+	return stars, nil
+}
+
+func (c *Client) GetUserStarsCount(userId int, since, until time.Time) (int, error) {
+	return c.GetUserStarsCountContext(context.Background(), userId, since, until)
+}
+
+// GetUserStarsCountContext returns the number of the stars received by the user between since and until. The zero time means the range is open on that side.
+func (c *Client) GetUserStarsCountContext(ctx context.Context, userId int, since, until time.Time) (int, error) {
+	ctx = withOperation(ctx, "GetUserStarsCount")
+
+	var err error
+	var response []byte
+	var count struct {
+		Count int `json:"count"`
+	}
+	var path *url.URL
+
+	query := url.Values{}
+
+	if !since.IsZero() {
+		query.Set("since", since.Format("2006-01-02"))
+	}
+	if !until.IsZero() {
+		query.Set("until", until.Format("2006-01-02"))
+	}
+	if path, err = c.root.Parse(fmt.Sprintf("./users/%v/stars/count", userId)); err != nil {
+		return 0, err
+	}
+	if response, err = c.getContext(ctx, path, query); err != nil {
+		return 0, err
+	}
+	if err = json.Unmarshal(response, &count); err != nil {
+		return 0, err
+	}
+
+	return count.Count, nil
+}
+
+func (c *Client) GetNotifications(query url.Values) ([]*Notification, error) {
+	return c.GetNotificationsContext(context.Background(), query)
+}
+
+// GetNotificationsContext returns the notifications for the user. The notifications can be paged with minId, maxId, count and order, and filtered with senderId in the query.
+func (c *Client) GetNotificationsContext(ctx context.Context, query url.Values) ([]*Notification, error) {
+	ctx = withOperation(ctx, "GetNotifications")
+
+	var err error
+	var response []byte
+	var notifications []*Notification
+	var path *url.URL
+
+	if query == nil {
+		query = url.Values{}
+	}
+	if path, err = c.root.Parse("./notifications"); err != nil {
+		return nil, err
+	}
+	if response, err = c.getContext(ctx, path, query); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &notifications); err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (c *Client) GetNotificationsCount(query url.Values) (int, error) {
+	return c.GetNotificationsCountContext(context.Background(), query)
+}
+
+// GetNotificationsCountContext returns the number of the notifications. The notifications can be filtered with alreadyRead and resourceAlreadyRead in the query.
+func (c *Client) GetNotificationsCountContext(ctx context.Context, query url.Values) (int, error) {
+	ctx = withOperation(ctx, "GetNotificationsCount")
+
+	var err error
+	var response []byte
+	var count struct {
+		Count int `json:"count"`
+	}
+	var path *url.URL
+
+	if path, err = c.root.Parse("./notifications/count"); err != nil {
+		return 0, err
+	}
+	if response, err = c.getContext(ctx, path, query); err != nil {
+		return 0, err
+	}
+	if err = json.Unmarshal(response, &count); err != nil {
+		return 0, err
+	}
+
+	return count.Count, nil
+}
+
+func (c *Client) ResetNotificationCount() (int, error) {
+	return c.ResetNotificationCountContext(context.Background())
+}
+
+// ResetNotificationCountContext resets the number of the unread notifications shown in the web UI and returns the number before resetting.
+func (c *Client) ResetNotificationCountContext(ctx context.Context) (int, error) {
+	ctx = withOperation(ctx, "ResetNotificationCount")
+
+	var err error
+	var response []byte
+	var count struct {
+		Count int `json:"count"`
+	}
+	var path *url.URL
+
+	errorPrefix := "ResetNotificationCountContext"
+	payload := bytes.NewBufferString("")
+
+	if path, err = c.root.Parse("./notifications/markAsRead"); err != nil {
+		return 0, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if response, err = c.postContext(ctx, path, nil, payload); err != nil {
+		return 0, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if err = json.Unmarshal(response, &count); err != nil {
+		return 0, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+
+	return count.Count, nil
+}
+
+func (c *Client) MarkNotificationRead(notificationId int) error {
+	return c.MarkNotificationReadContext(context.Background(), notificationId)
+}
+
+func (c *Client) MarkNotificationReadContext(ctx context.Context, notificationId int) error {
+	ctx = withOperation(ctx, "MarkNotificationRead")
+
+	var err error
+	var path *url.URL
+
+	errorPrefix := "MarkNotificationReadContext"
+	payload := bytes.NewBufferString("")
+
+	if path, err = c.root.Parse(fmt.Sprintf("./notifications/%v/markAsRead", notificationId)); err != nil {
+		return fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if _, err = c.postContext(ctx, path, nil, payload); err != nil {
+		return fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+
+	return nil
+}
+
+func (c *Client) GetWatchings(userId int, query url.Values) ([]*Watching, error) {
+	return c.GetWatchingsContext(context.Background(), userId, query)
+}
+
+// GetWatchingsContext returns the watchings of the user. The watchings can be paged with count and offset, and filtered with resourceAlreadyRead and issueId[] in the query.
+func (c *Client) GetWatchingsContext(ctx context.Context, userId int, query url.Values) ([]*Watching, error) {
+	ctx = withOperation(ctx, "GetWatchings")
+
+	var err error
+	var response []byte
+	var watchings []*Watching
+	var path *url.URL
+
+	if query == nil {
+		query = url.Values{}
+	}
+	if path, err = c.root.Parse(fmt.Sprintf("./users/%v/watchings", userId)); err != nil {
+		return nil, err
+	}
+	if response, err = c.getContext(ctx, path, query); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &watchings); err != nil {
+		return nil, err
+	}
+
+	return watchings, nil
+}
+
+func (c *Client) GetWatchingsCount(userId int, query url.Values) (int, error) {
+	return c.GetWatchingsCountContext(context.Background(), userId, query)
+}
+
+func (c *Client) GetWatchingsCountContext(ctx context.Context, userId int, query url.Values) (int, error) {
+	ctx = withOperation(ctx, "GetWatchingsCount")
+
+	var err error
+	var response []byte
+	var count struct {
+		Count int `json:"count"`
+	}
+	var path *url.URL
+
+	if path, err = c.root.Parse(fmt.Sprintf("./users/%v/watchings/count", userId)); err != nil {
+		return 0, err
+	}
+	if response, err = c.getContext(ctx, path, query); err != nil {
+		return 0, err
+	}
+	if err = json.Unmarshal(response, &count); err != nil {
+		return 0, err
+	}
+
+	return count.Count, nil
+}
+
+func (c *Client) GetWatching(watchingId int) (*Watching, error) {
+	return c.GetWatchingContext(context.Background(), watchingId)
+}
+
+func (c *Client) GetWatchingContext(ctx context.Context, watchingId int) (*Watching, error) {
+	ctx = withOperation(ctx, "GetWatching")
+
+	var err error
+	var response []byte
+	var watching Watching
+	var path *url.URL
+
+	if path, err = c.root.Parse(fmt.Sprintf("./watchings/%v", watchingId)); err != nil {
+		return nil, err
+	}
+	if response, err = c.getContext(ctx, path, nil); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &watching); err != nil {
+		return nil, err
+	}
+
+	return &watching, nil
+}
+
+func (c *Client) AddWatching(issueId, note string) (*Watching, error) {
+	return c.AddWatchingContext(context.Background(), issueId, note)
+}
+
+func (c *Client) AddWatchingContext(ctx context.Context, issueId, note string) (*Watching, error) {
+	ctx = withOperation(ctx, "AddWatching")
+
+	var err error
+	var response []byte
+	var watching Watching
+	var path *url.URL
+
+	errorPrefix := "AddWatchingContext"
+	values := url.Values{}
+	values.Set("issueIdOrKey", issueId)
+
+	if note != "" {
+		values.Set("note", note)
+	}
+
+	payload := bytes.NewBufferString(values.Encode())
+
+	if path, err = c.root.Parse("./watchings"); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if response, err = c.postContext(ctx, path, nil, payload); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if err = json.Unmarshal(response, &watching); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+
+	return &watching, nil
+}
+
+func (c *Client) UpdateWatching(watchingId int, note string) (*Watching, error) {
+	return c.UpdateWatchingContext(context.Background(), watchingId, note)
+}
+
+func (c *Client) UpdateWatchingContext(ctx context.Context, watchingId int, note string) (*Watching, error) {
+	ctx = withOperation(ctx, "UpdateWatching")
+
+	var err error
+	var response []byte
+	var watching Watching
+	var path *url.URL
+
+	errorPrefix := "UpdateWatchingContext"
+	values := url.Values{}
+	values.Set("note", note)
+	payload := bytes.NewBufferString(values.Encode())
+
+	if path, err = c.root.Parse(fmt.Sprintf("./watchings/%v", watchingId)); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if response, err = c.patchContext(ctx, path, nil, payload); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if err = json.Unmarshal(response, &watching); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+
+	return &watching, nil
+}
+
+func (c *Client) DeleteWatching(watchingId int) (*Watching, error) {
+	return c.DeleteWatchingContext(context.Background(), watchingId)
+}
+
+func (c *Client) DeleteWatchingContext(ctx context.Context, watchingId int) (*Watching, error) {
+	ctx = withOperation(ctx, "DeleteWatching")
+
+	var err error
+	var response []byte
+	var watching Watching
+	var path *url.URL
+
+	if path, err = c.root.Parse(fmt.Sprintf("./watchings/%v", watchingId)); err != nil {
+		return nil, err
+	}
+	if response, err = c.deleteContext(ctx, path, nil); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &watching); err != nil {
+		return nil, err
+	}
+
+	return &watching, nil
+}
+
+func (c *Client) MarkWatchingRead(watchingId int) error {
+	return c.MarkWatchingReadContext(context.Background(), watchingId)
+}
+
+func (c *Client) MarkWatchingReadContext(ctx context.Context, watchingId int) error {
+	ctx = withOperation(ctx, "MarkWatchingRead")
+
+	var err error
+	var path *url.URL
+
+	errorPrefix := "MarkWatchingReadContext"
+	payload := bytes.NewBufferString("")
+
+	if path, err = c.root.Parse(fmt.Sprintf("./watchings/%v/markAsRead", watchingId)); err != nil {
+		return fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if _, err = c.postContext(ctx, path, nil, payload); err != nil {
+		return fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+
+	return nil
+}
+
+func (c *Client) GetWebhooks(projectId string) ([]*Webhook, error) {
+	return c.GetWebhooksContext(context.Background(), projectId)
+}
+
+func (c *Client) GetWebhooksContext(ctx context.Context, projectId string) ([]*Webhook, error) {
+	ctx = withOperation(ctx, "GetWebhooks")
+
+	var err error
+	var response []byte
+	var webhooks []*Webhook
+	var path *url.URL
+
+	if path, err = c.root.Parse(fmt.Sprintf("./projects/%v/webhooks", projectId)); err != nil {
+		return nil, err
+	}
+	if response, err = c.getContext(ctx, path, nil); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &webhooks); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (c *Client) AddWebhook(projectId string, webhook *Webhook) (*Webhook, error) {
+	return c.AddWebhookContext(context.Background(), projectId, webhook)
+}
+
+// AddWebhookContext registers the webhook to the project. Only Name, Description, HookURL, AllEvent and ActivityTypeIds are used.
+func (c *Client) AddWebhookContext(ctx context.Context, projectId string, webhook *Webhook) (*Webhook, error) {
+	ctx = withOperation(ctx, "AddWebhook")
+
+	var err error
+	var response []byte
+	var created Webhook
+	var path *url.URL
+
+	errorPrefix := "AddWebhookContext"
+	payload := bytes.NewBufferString(webhook.values().Encode())
+
+	if path, err = c.root.Parse(fmt.Sprintf("./projects/%v/webhooks", projectId)); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if response, err = c.postContext(ctx, path, nil, payload); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if err = json.Unmarshal(response, &created); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+
+	return &created, nil
+}
+
+func (c *Client) GetWebhook(projectId string, webhookId int) (*Webhook, error) {
+	return c.GetWebhookContext(context.Background(), projectId, webhookId)
+}
+
+func (c *Client) GetWebhookContext(ctx context.Context, projectId string, webhookId int) (*Webhook, error) {
+	ctx = withOperation(ctx, "GetWebhook")
+
+	var err error
+	var response []byte
+	var webhook Webhook
+	var path *url.URL
+
+	if path, err = c.root.Parse(fmt.Sprintf("./projects/%v/webhooks/%v", projectId, webhookId)); err != nil {
+		return nil, err
+	}
+	if response, err = c.getContext(ctx, path, nil); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &webhook); err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (c *Client) UpdateWebhook(projectId string, webhook *Webhook) (*Webhook, error) {
+	return c.UpdateWebhookContext(context.Background(), projectId, webhook)
+}
+
+// UpdateWebhookContext updates the webhook identified by webhook.Id. Only Name, Description, HookURL, AllEvent and ActivityTypeIds are used, and the empty fields are left unchanged.
+func (c *Client) UpdateWebhookContext(ctx context.Context, projectId string, webhook *Webhook) (*Webhook, error) {
+	ctx = withOperation(ctx, "UpdateWebhook")
+
+	var err error
+	var response []byte
+	var updated Webhook
+	var path *url.URL
+
+	errorPrefix := "UpdateWebhookContext"
+	payload := bytes.NewBufferString(webhook.updateValues().Encode())
+
+	if path, err = c.root.Parse(fmt.Sprintf("./projects/%v/webhooks/%v", projectId, webhook.Id)); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if response, err = c.patchContext(ctx, path, nil, payload); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if err = json.Unmarshal(response, &updated); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+
+	return &updated, nil
+}
+
+func (c *Client) DeleteWebhook(projectId string, webhookId int) (*Webhook, error) {
+	return c.DeleteWebhookContext(context.Background(), projectId, webhookId)
+}
+
+func (c *Client) DeleteWebhookContext(ctx context.Context, projectId string, webhookId int) (*Webhook, error) {
+	ctx = withOperation(ctx, "DeleteWebhook")
+
+	var err error
+	var response []byte
+	var webhook Webhook
+	var path *url.URL
+
+	if path, err = c.root.Parse(fmt.Sprintf("./projects/%v/webhooks/%v", projectId, webhookId)); err != nil {
+		return nil, err
+	}
+	if response, err = c.deleteContext(ctx, path, nil); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &webhook); err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (c *Client) GetPullRequestComments(projectId, repositoryId string, number int, query url.Values) ([]*PullRequestComment, error) {
+	return c.GetPullRequestCommentsContext(context.Background(), projectId, repositoryId, number, query)
+}
+
+// GetPullRequestCommentsContext returns the comments on the pull request. The comments can be paged with minId, maxId, count and order in the query.
+func (c *Client) GetPullRequestCommentsContext(ctx context.Context, projectId, repositoryId string, number int, query url.Values) ([]*PullRequestComment, error) {
+	ctx = withOperation(ctx, "GetPullRequestComments")
+
+	var err error
+	var response []byte
+	var comments []*PullRequestComment
+	var path *url.URL
+
+	if query == nil {
+		query = url.Values{}
+	}
+	if path, err = c.root.Parse(fmt.Sprintf("./projects/%v/git/repositories/%v/pullRequests/%v/comments", projectId, repositoryId, number)); err != nil {
+		return nil, err
+	}
+	if response, err = c.getContext(ctx, path, query); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &comments); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func (c *Client) AddPullRequestComment(projectId, repositoryId string, number int, content string, notifiedUserIds []int) (*PullRequestComment, error) {
+	return c.AddPullRequestCommentContext(context.Background(), projectId, repositoryId, number, content, notifiedUserIds)
+}
+
+func (c *Client) AddPullRequestCommentContext(ctx context.Context, projectId, repositoryId string, number int, content string, notifiedUserIds []int) (*PullRequestComment, error) {
+	ctx = withOperation(ctx, "AddPullRequestComment")
+
+	var err error
+	var response []byte
+	var comment PullRequestComment
+	var path *url.URL
+
+	errorPrefix := "AddPullRequestCommentContext"
+	values := url.Values{}
+	values.Set("content", content)
+
+	for _, id := range notifiedUserIds {
+		values.Add("notifiedUserId[]", strconv.Itoa(id))
+	}
+
+	payload := bytes.NewBufferString(values.Encode())
+
+	if path, err = c.root.Parse(fmt.Sprintf("./projects/%v/git/repositories/%v/pullRequests/%v/comments", projectId, repositoryId, number)); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if response, err = c.postContext(ctx, path, nil, payload); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if err = json.Unmarshal(response, &comment); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+
+	return &comment, nil
+}
+
+func (c *Client) GetPullRequestCommentsCount(projectId, repositoryId string, number int) (int, error) {
+	return c.GetPullRequestCommentsCountContext(context.Background(), projectId, repositoryId, number)
+}
+
+func (c *Client) GetPullRequestCommentsCountContext(ctx context.Context, projectId, repositoryId string, number int) (int, error) {
+	ctx = withOperation(ctx, "GetPullRequestCommentsCount")
+
+	var err error
+	var response []byte
+	var count struct {
+		Count int `json:"count"`
+	}
+	var path *url.URL
+
+	if path, err = c.root.Parse(fmt.Sprintf("./projects/%v/git/repositories/%v/pullRequests/%v/comments/count", projectId, repositoryId, number)); err != nil {
+		return 0, err
+	}
+	if response, err = c.getContext(ctx, path, nil); err != nil {
+		return 0, err
+	}
+	if err = json.Unmarshal(response, &count); err != nil {
+		return 0, err
+	}
+
+	return count.Count, nil
+}
+
+func (c *Client) UpdatePullRequestComment(projectId, repositoryId string, number, commentId int, content string) (*PullRequestComment, error) {
+	return c.UpdatePullRequestCommentContext(context.Background(), projectId, repositoryId, number, commentId, content)
+}
+
+func (c *Client) UpdatePullRequestCommentContext(ctx context.Context, projectId, repositoryId string, number, commentId int, content string) (*PullRequestComment, error) {
+	ctx = withOperation(ctx, "UpdatePullRequestComment")
+
+	var err error
+	var response []byte
+	var comment PullRequestComment
+	var path *url.URL
+
+	errorPrefix := "UpdatePullRequestCommentContext"
+	values := url.Values{}
+	values.Set("content", content)
+	payload := bytes.NewBufferString(values.Encode())
+
+	if path, err = c.root.Parse(fmt.Sprintf("./projects/%v/git/repositories/%v/pullRequests/%v/comments/%v", projectId, repositoryId, number, commentId)); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if response, err = c.patchContext(ctx, path, nil, payload); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if err = json.Unmarshal(response, &comment); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+
+	return &comment, nil
+}
+
+func (c *Client) UploadAttachment(name string, file io.Reader) (*Attachment, error) {
+	return c.UploadAttachmentContext(context.Background(), name, file)
+}
+
+// UploadAttachmentContext uploads the file to the space. The id of the returned attachment is used to attach the file to issues, wikis and pull requests.
+func (c *Client) UploadAttachmentContext(ctx context.Context, name string, file io.Reader) (*Attachment, error) {
+	ctx = withOperation(ctx, "UploadAttachment")
+
+	var err error
+	var response []byte
+	var attachment Attachment
+	var path *url.URL
+
+	errorPrefix := "UploadAttachmentContext"
+	payload := &bytes.Buffer{}
+	writer := multipart.NewWriter(payload)
+
+	part, err := writer.CreateFormFile("file", name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if _, err = io.Copy(part, file); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if err = writer.Close(); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if path, err = c.root.Parse("./space/attachment"); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if response, err = c.postMultipartContext(ctx, path, nil, writer.FormDataContentType(), payload); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+	if err = json.Unmarshal(response, &attachment); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+	}
+
+	return &attachment, nil
+}
+
+func (c *Client) GetPullRequestAttachments(projectId, repositoryId string, number int) ([]*Attachment, error) {
+	return c.GetPullRequestAttachmentsContext(context.Background(), projectId, repositoryId, number)
+}
+
+func (c *Client) GetPullRequestAttachmentsContext(ctx context.Context, projectId, repositoryId string, number int) ([]*Attachment, error) {
+	ctx = withOperation(ctx, "GetPullRequestAttachments")
+
+	var err error
+	var response []byte
+	var attachments []*Attachment
+	var path *url.URL
+
+	if path, err = c.root.Parse(fmt.Sprintf("./projects/%v/git/repositories/%v/pullRequests/%v/attachments", projectId, repositoryId, number)); err != nil {
+		return nil, err
+	}
+	if response, err = c.getContext(ctx, path, nil); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &attachments); err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (c *Client) DownloadPullRequestAttachment(projectId, repositoryId string, number, attachmentId int) ([]byte, error) {
+	return c.DownloadPullRequestAttachmentContext(context.Background(), projectId, repositoryId, number, attachmentId)
+}
+
+func (c *Client) DownloadPullRequestAttachmentContext(ctx context.Context, projectId, repositoryId string, number, attachmentId int) ([]byte, error) {
+	ctx = withOperation(ctx, "DownloadPullRequestAttachment")
+
+	var err error
+	var path *url.URL
+
+	if path, err = c.root.Parse(fmt.Sprintf("./projects/%v/git/repositories/%v/pullRequests/%v/attachments/%v", projectId, repositoryId, number, attachmentId)); err != nil {
+		return nil, err
+	}
+
+	return c.getContext(ctx, path, nil)
+}
+
+func (c *Client) DeletePullRequestAttachment(projectId, repositoryId string, number, attachmentId int) (*Attachment, error) {
+	return c.DeletePullRequestAttachmentContext(context.Background(), projectId, repositoryId, number, attachmentId)
+}
+
+func (c *Client) DeletePullRequestAttachmentContext(ctx context.Context, projectId, repositoryId string, number, attachmentId int) (*Attachment, error) {
+	ctx = withOperation(ctx, "DeletePullRequestAttachment")
+
+	var err error
+	var response []byte
+	var attachment Attachment
+	var path *url.URL
+
+	if path, err = c.root.Parse(fmt.Sprintf("./projects/%v/git/repositories/%v/pullRequests/%v/attachments/%v", projectId, repositoryId, number, attachmentId)); err != nil {
+		return nil, err
+	}
+	if response, err = c.deleteContext(ctx, path, nil); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &attachment); err != nil {
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+func (c *Client) GetLicence() (*Licence, error) {
+	return c.GetLicenceContext(context.Background())
+}
+
+func (c *Client) GetLicenceContext(ctx context.Context) (*Licence, error) {
+	ctx = withOperation(ctx, "GetLicence")
+
+	var err error
+	var response []byte
+	var licence Licence
+	var path *url.URL
+
+	if path, err = c.root.Parse("./space/licence"); err != nil {
+		return nil, err
+	}
+	if response, err = c.getContext(ctx, path, nil); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &licence); err != nil {
+		return nil, err
+	}
+
+	return &licence, nil
+}
+
+func (c *Client) GetRateLimit() (*RateLimit, error) {
+	return c.GetRateLimitContext(context.Background())
+}
+
+func (c *Client) GetRateLimitContext(ctx context.Context) (*RateLimit, error) {
+	ctx = withOperation(ctx, "GetRateLimit")
+
+	var err error
+	var response []byte
+	var rateLimit struct {
+		RateLimit RateLimit `json:"rateLimit"`
+	}
+	var path *url.URL
+
+	if path, err = c.root.Parse("./rateLimit"); err != nil {
+		return nil, err
+	}
+	if response, err = c.getContext(ctx, path, nil); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(response, &rateLimit); err != nil {
+		return nil, err
+	}
+
+	return &rateLimit.RateLimit, nil
+}
+
+func (c *Client) GetCapabilities() (Capabilities, error) {
+	return c.GetCapabilitiesContext(context.Background())
+}
+
+// GetCapabilitiesContext reports which optional features are enabled for the space. Use Capabilities.ForProject to take the project settings into account.
+func (c *Client) GetCapabilitiesContext(ctx context.Context) (Capabilities, error) {
+	ctx = withOperation(ctx, "GetCapabilities")
+
+	licence, err := c.GetLicenceContext(ctx)
+	if err != nil {
+		return Capabilities{}, err
+	}
+
+	return newCapabilities(licence), nil
+}
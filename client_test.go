@@ -1,14 +1,23 @@
 package backlog
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
 	"io/ioutil"
+	"log"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 )
 
 var (
@@ -85,7 +94,7 @@ func TestGetIssues(t *testing.T) {
 }
 
 func TestGetIssue(t *testing.T) {
-	_, err := client.GetIssue(12345)
+	_, err := client.GetIssue("12345")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -101,7 +110,7 @@ func TestDeleteIssue(t *testing.T) {
 }
 
 func TestSetIssue(t *testing.T) {
-	_, err := client.SetIssue(12345, nil)
+	_, err := client.SetIssue("12345", nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -139,3 +148,865 @@ func TestGetPriorities(t *testing.T) {
 	}
 	return
 }
+
+func TestAddComment(t *testing.T) {
+	_, err := client.AddComment("12345", "deployed to staging", []int{137435}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetComment(t *testing.T) {
+	_, err := client.GetComment("12345", 67890)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestUpdateComment(t *testing.T) {
+	_, err := client.UpdateComment("12345", 67890, "deployed to production")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestDeleteComment(t *testing.T) {
+	_, err := client.DeleteComment("12345", 67890)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetCommentsCount(t *testing.T) {
+	_, err := client.GetCommentsCount("12345")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetCommentNotifications(t *testing.T) {
+	_, err := client.GetCommentNotifications("12345", 67890)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestAddCommentNotification(t *testing.T) {
+	_, err := client.AddCommentNotification("12345", 67890, []int{137435})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetIssueHistory(t *testing.T) {
+	history, err := client.GetIssueHistory("12345")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before := history.At(time.Date(2017, 8, 8, 0, 59, 0, 0, time.UTC))
+	if got := before.Fields[ChangeLogFieldStatus]; got != "open" {
+		t.Fatalf("expected open, got %v", got)
+	}
+	if got := before.CustomFields[55]; got != "low" {
+		t.Fatalf("expected low, got %v", got)
+	}
+
+	after := history.At(time.Date(2017, 8, 8, 1, 0, 0, 0, time.UTC))
+	if got := after.Fields[ChangeLogFieldStatus]; got != "ongoing" {
+		t.Fatalf("expected ongoing, got %v", got)
+	}
+	if got := after.CustomFields[55]; got != "high" {
+		t.Fatalf("expected high, got %v", got)
+	}
+	return
+}
+
+func TestIssueHistoryParentIssue(t *testing.T) {
+	changed := time.Date(2017, 8, 8, 1, 0, 0, 0, time.UTC)
+	history := newIssueHistory(&Issue{Summary: "summary", ParentIssueId: 5}, []*Comment{{
+		Id:      1,
+		Created: Date(changed.Format(time.RFC3339)),
+		ChangeLog: []ChangeLog{
+			{Field: ChangeLogFieldParentIssue, OriginalValue: "", NewValue: "SAMPLE-5"},
+		},
+	}})
+
+	before := history.At(changed.Add(-time.Minute))
+	if _, ok := before.Fields[ChangeLogFieldParentIssue]; ok {
+		t.Fatalf("unexpected parent issue %v", before.Fields[ChangeLogFieldParentIssue])
+	}
+	if got := before.Fields[ChangeLogFieldSummary]; got != "summary" {
+		t.Fatalf("expected summary, got %v", got)
+	}
+	if changes := history.ChangesOf(ChangeLogFieldParentIssue); len(changes) != 1 || changes[0].ChangeLog.NewValue != "SAMPLE-5" {
+		t.Fatalf("unexpected changes %+v", changes)
+	}
+	return
+}
+
+func TestGetWikis(t *testing.T) {
+	_, err := client.GetWikis("SAMPLE", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetWikisCount(t *testing.T) {
+	_, err := client.GetWikisCount("SAMPLE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetWikiTags(t *testing.T) {
+	_, err := client.GetWikiTags("SAMPLE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestAddWiki(t *testing.T) {
+	_, err := client.AddWiki(51884, "Runbooks/Deploy", "# Deploy", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetWiki(t *testing.T) {
+	_, err := client.GetWiki(112)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestUpdateWiki(t *testing.T) {
+	_, err := client.UpdateWiki(112, "", "# Deploy", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestDeleteWiki(t *testing.T) {
+	_, err := client.DeleteWiki(112, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetWikiAttachments(t *testing.T) {
+	_, err := client.GetWikiAttachments(112)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestAddWikiAttachments(t *testing.T) {
+	_, err := client.AddWikiAttachments(112, []int{8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestDownloadWikiAttachment(t *testing.T) {
+	_, err := client.DownloadWikiAttachment(112, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestDeleteWikiAttachment(t *testing.T) {
+	_, err := client.DeleteWikiAttachment(112, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetWikiSharedFiles(t *testing.T) {
+	_, err := client.GetWikiSharedFiles(112)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestLinkWikiSharedFiles(t *testing.T) {
+	_, err := client.LinkWikiSharedFiles(112, []int{454403})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestUnlinkWikiSharedFile(t *testing.T) {
+	_, err := client.UnlinkWikiSharedFile(112, 454403)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetWikiHistory(t *testing.T) {
+	_, err := client.GetWikiHistory(112, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetWikiStars(t *testing.T) {
+	_, err := client.GetWikiStars(112)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestAddStar(t *testing.T) {
+	err := client.AddStar(StarTargetIssue, 12345)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestRemoveStar(t *testing.T) {
+	err := client.RemoveStar(75)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetUserStars(t *testing.T) {
+	_, err := client.GetUserStars(137435, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetUserStarsCount(t *testing.T) {
+	_, err := client.GetUserStarsCount(137435, time.Date(2017, 8, 1, 0, 0, 0, 0, time.UTC), time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetNotifications(t *testing.T) {
+	notifications, err := client.GetNotifications(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if notifications[0].Reason != NotificationReasonCommented {
+		t.Fatalf("expected %v, got %v", NotificationReasonCommented, notifications[0].Reason)
+	}
+	return
+}
+
+func TestGetNotificationsCount(t *testing.T) {
+	_, err := client.GetNotificationsCount(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestResetNotificationCount(t *testing.T) {
+	_, err := client.ResetNotificationCount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestMarkNotificationRead(t *testing.T) {
+	err := client.MarkNotificationRead(22)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetWatchings(t *testing.T) {
+	_, err := client.GetWatchings(137435, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetWatchingsCount(t *testing.T) {
+	_, err := client.GetWatchingsCount(137435, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetWatching(t *testing.T) {
+	_, err := client.GetWatching(31)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestAddWatching(t *testing.T) {
+	_, err := client.AddWatching("12345", "on-call")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestUpdateWatching(t *testing.T) {
+	_, err := client.UpdateWatching(31, "on-call")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestDeleteWatching(t *testing.T) {
+	_, err := client.DeleteWatching(31)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestMarkWatchingRead(t *testing.T) {
+	err := client.MarkWatchingRead(31)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetWebhooks(t *testing.T) {
+	_, err := client.GetWebhooks("SAMPLE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestAddWebhook(t *testing.T) {
+	_, err := client.AddWebhook("SAMPLE", &Webhook{
+		Name:            "deploy",
+		HookURL:         "https://example.com/hook",
+		ActivityTypeIds: []ActivityType{ActivityTypeIssueCreated, ActivityTypeIssueUpdated},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetWebhook(t *testing.T) {
+	_, err := client.GetWebhook("SAMPLE", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	_, err := client.UpdateWebhook("SAMPLE", &Webhook{Id: 3, Name: "deploy", AllEvent: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestWebhookUpdateValues(t *testing.T) {
+	values := (&Webhook{Id: 3, Name: "deploy", AllEvent: true}).updateValues()
+
+	if values.Encode() != "allEvent=true&name=deploy" {
+		t.Fatalf("unexpected values %v", values.Encode())
+	}
+	return
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	_, err := client.DeleteWebhook("SAMPLE", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetRepositories(t *testing.T) {
+	_, err := client.GetRepositories("SAMPLE", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetRepository(t *testing.T) {
+	repository, err := client.GetRepository("SAMPLE", "webapp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := repository.BranchURL("feature/login"); got != "https://xx.backlog.jp/git/SAMPLE/webapp/tree/feature/login" {
+		t.Fatalf("unexpected branch URL %v", got)
+	}
+	return
+}
+
+func TestGetPullRequestComments(t *testing.T) {
+	_, err := client.GetPullRequestComments("SAMPLE", "webapp", 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestAddPullRequestComment(t *testing.T) {
+	_, err := client.AddPullRequestComment("SAMPLE", "webapp", 1, "All tests passed.", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetPullRequestCommentsCount(t *testing.T) {
+	_, err := client.GetPullRequestCommentsCount("SAMPLE", "webapp", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestUpdatePullRequestComment(t *testing.T) {
+	_, err := client.UpdatePullRequestComment("SAMPLE", "webapp", 1, 35, "All tests passed.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestUploadAttachment(t *testing.T) {
+	_, err := client.UploadAttachment("deploy.png", strings.NewReader("PNG"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetPullRequestAttachments(t *testing.T) {
+	_, err := client.GetPullRequestAttachments("SAMPLE", "webapp", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestDownloadPullRequestAttachment(t *testing.T) {
+	_, err := client.DownloadPullRequestAttachment("SAMPLE", "webapp", 1, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestDeletePullRequestAttachment(t *testing.T) {
+	_, err := client.DeletePullRequestAttachment("SAMPLE", "webapp", 1, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetPullRequests(t *testing.T) {
+	query := &PullRequestQuery{Statuses: []PullRequestStatus{PullRequestStatusOpen}, AssigneeIds: []int{137435}}
+	_, err := client.GetPullRequests("SAMPLE", "webapp", query.Values())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetPullRequest(t *testing.T) {
+	pullRequest, err := client.GetPullRequest("SAMPLE", "webapp", 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pullRequest.IsMerged() {
+		t.Fatalf("expected merged, got %v", pullRequest.Status)
+	}
+	if got := pullRequest.TimeToMerge(); got != 24*time.Hour {
+		t.Fatalf("expected 24h, got %v", got)
+	}
+	return
+}
+
+func TestGetPullRequestsCount(t *testing.T) {
+	_, err := client.GetPullRequestsCount("SAMPLE", "webapp", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestCreatePullRequest(t *testing.T) {
+	input := &CreatePullRequestInput{
+		Summary:       "Add login page",
+		Base:          "master",
+		Branch:        "feature/login",
+		AttachmentIds: []int{8},
+	}
+	_, err := client.CreatePullRequest("SAMPLE", "webapp", input.Values())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestUpdatePullRequest(t *testing.T) {
+	input := &UpdatePullRequestInput{Comment: "Rebased onto master."}
+	_, err := client.UpdatePullRequest("SAMPLE", "webapp", 1, input.Values())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetLicence(t *testing.T) {
+	_, err := client.GetLicence()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetRateLimit(t *testing.T) {
+	_, err := client.GetRateLimit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestGetCapabilities(t *testing.T) {
+	capabilities, err := client.GetCapabilities()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !capabilities.Git {
+		t.Fatal("expected git to be enabled")
+	}
+	if capabilities.ForProject(&Project{UseWiki: false}).Wiki {
+		t.Fatal("expected wiki to be disabled")
+	}
+	return
+}
+
+func TestSetLogHandler(t *testing.T) {
+	var buffer bytes.Buffer
+
+	client.SetLogHandler(slog.NewTextHandler(&buffer, &slog.HandlerOptions{Level: slog.LevelDebug}), &LogOptions{Bodies: true, MaxBodyBytes: 16})
+	defer client.SetLogHandler(slog.NewTextHandler(ioutil.Discard, nil), nil)
+
+	_, err := client.AddComment("12345", "deployed to staging", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output := buffer.String()
+
+	if strings.Contains(output, "XXXXXXXX") {
+		t.Fatalf("API key is logged: %s", output)
+	}
+	if !strings.Contains(output, "status=200") || !strings.Contains(output, "(truncated)") {
+		t.Fatalf("unexpected log: %s", output)
+	}
+	return
+}
+
+func TestSetLogger(t *testing.T) {
+	var buffer bytes.Buffer
+
+	c, _ := New("spaceName", "XXXXXXXX")
+	c.root = client.root
+	c.SetLogger(log.New(&buffer, "backlog: ", 0))
+
+	if _, err := c.GetStatuses(); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "backlog: level=") {
+			t.Fatalf("the prefix of the logger is dropped: %q", line)
+		}
+	}
+	if len(lines) != 2 || !strings.Contains(lines[1], "msg=response") || !strings.Contains(lines[1], "status=200") {
+		t.Fatalf("unexpected log: %s", buffer.String())
+	}
+	if strings.Contains(buffer.String(), "XXXXXXXX") {
+		t.Fatalf("API key is logged: %s", buffer.String())
+	}
+	return
+}
+
+func TestTransportErrorIsRedacted(t *testing.T) {
+	c, _ := New("spaceName", "XXXXXXXX")
+	c.root = client.root
+	c.SetHTTPClient(DoerFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, &url.Error{Op: "Get", URL: req.URL.String(), Err: io.ErrUnexpectedEOF}
+	}))
+
+	_, err := c.GetStatuses()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if strings.Contains(err.Error(), "XXXXXXXX") {
+		t.Fatalf("API key is returned: %v", err)
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return
+}
+
+func TestLogBodyTruncatesOnRuneBoundary(t *testing.T) {
+	attr := logBody(&LogOptions{Bodies: true, MaxBodyBytes: 4}, "application/json", []byte(`"課題"`))
+
+	if body := attr.Value.String(); !utf8.ValidString(body) || body != `"課...(truncated)` {
+		t.Fatalf("unexpected body: %q", body)
+	}
+	return
+}
+
+func TestUse(t *testing.T) {
+	c, _ := New("spaceName", "XXXXXXXX")
+	c.root = client.root
+
+	var order []string
+
+	for _, name := range []string{"outer", "inner"} {
+		name := name
+		c.Use(func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.Do(req)
+			})
+		})
+	}
+
+	var operation string
+
+	c.Use(func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			operation = OperationName(req.Context())
+			return next.Do(req)
+		})
+	})
+
+	_, err := c.GetStatuses()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if operation != "GetStatuses" {
+		t.Fatalf("expected GetStatuses, got %v", operation)
+	}
+	if strings.Join(order, ",") != "outer,inner" {
+		t.Fatalf("unexpected order %v", order)
+	}
+	return
+}
+
+func TestRetryMiddleware(t *testing.T) {
+	c, _ := New("spaceName", "XXXXXXXX")
+	c.root = client.root
+
+	attempts := 0
+	c.SetHTTPClient(DoerFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts < 3 {
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(strings.NewReader(`{"errors":[{"message":"Too many requests.","code":9}]}`)),
+			}, nil
+		}
+		return http.DefaultClient.Do(req)
+	}))
+	c.Use(RetryMiddleware(3, time.Millisecond))
+
+	_, err := c.AddComment("12345", "deployed to staging", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+	return
+}
+
+func TestRetryMiddlewareBackoff(t *testing.T) {
+	c, _ := New("spaceName", "XXXXXXXX")
+	c.root = client.root
+
+	attempts := 0
+	c.SetHTTPClient(DoerFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts < 3 {
+			header := http.Header{}
+			header.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10))
+
+			return &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Header:     header,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"errors":[{"message":"Service unavailable.","code":1}]}`)),
+			}, nil
+		}
+		return http.DefaultClient.Do(req)
+	}))
+	c.Use(RetryMiddleware(3, 20*time.Millisecond))
+
+	start := time.Now()
+
+	if _, err := c.GetStatuses(); err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+	// The reset time is ignored for 5xx, so the retries wait 20ms and 40ms.
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Fatalf("expected backoff, got %v", elapsed)
+	}
+	return
+}
+
+func TestRetryMiddlewareMaxWait(t *testing.T) {
+	defer func(d time.Duration) { maxRetryWait = d }(maxRetryWait)
+	maxRetryWait = 10 * time.Millisecond
+
+	c, _ := New("spaceName", "XXXXXXXX")
+	c.root = client.root
+
+	attempts := 0
+	c.SetHTTPClient(DoerFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		header := http.Header{}
+		header.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+
+		return &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     header,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"errors":[{"message":"Too many requests.","code":9}]}`)),
+		}, nil
+	}))
+	c.Use(RetryMiddleware(2, time.Millisecond))
+
+	started := time.Now()
+
+	if _, err := c.GetStatuses(); err == nil {
+		t.Fatal("expected error")
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("the wait is not capped: %v", elapsed)
+	}
+
+	// The wait ends when the context is done.
+	maxRetryWait = time.Hour
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	started = time.Now()
+
+	if _, err := c.GetStatusesContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("the wait does not end with the context: %v", elapsed)
+	}
+	return
+}
+
+func TestSetDryRun(t *testing.T) {
+	c, _ := New("spaceName", "XXXXXXXX")
+	c.root = client.root
+	c.SetDryRun(true)
+
+	values := url.Values{}
+	values.Set("projectId", "51884")
+	values.Set("summary", "summery of the issue")
+	values.Set("issueTypeId", "234158")
+	values.Set("priorityId", "3")
+
+	if issue, err := c.CreateIssue(values); err != nil || issue == nil {
+		t.Fatalf("expected the empty issue, got %v, %v", issue, err)
+	}
+	if issue, err := c.DeleteIssue(12345); err != nil || issue == nil {
+		t.Fatalf("expected the empty issue, got %v, %v", issue, err)
+	}
+	if attachments, err := c.AddWikiAttachments(34, []int{1, 2}); err != nil || len(attachments) != 0 {
+		t.Fatalf("expected no attachments, got %v, %v", attachments, err)
+	}
+
+	values.Del("summary")
+
+	var e Error
+
+	if _, err := c.CreateIssue(values); !errors.As(err, &e) || e.Code != 7 {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+
+	requests := c.DryRunRequests()
+	if len(requests) != 3 {
+		t.Fatalf("expected 2 requests, got %v", requests)
+	}
+	if requests[0].Method != "POST" || requests[0].Path != "issues" || requests[0].Values.Get("summary") != "summery of the issue" {
+		t.Fatalf("unexpected request %+v", requests[0])
+	}
+	if requests[1].Method != "DELETE" || requests[1].Path != "issues/12345" {
+		t.Fatalf("unexpected request %+v", requests[1])
+	}
+	if requests[2].Method != "POST" || requests[2].Path != "wikis/34/attachments" || len(requests[2].Values["attachmentId[]"]) != 2 {
+		t.Fatalf("unexpected request %+v", requests[2])
+	}
+
+	// GET requests are sent as usual.
+	if _, err := c.GetStatuses(); err != nil {
+		t.Fatal(err)
+	}
+
+	c.SetDryRun(false)
+
+	if requests := c.DryRunRequests(); len(requests) != 0 {
+		t.Fatalf("expected no requests, got %v", requests)
+	}
+	if _, err := c.DeleteIssue(12345); err != nil {
+		t.Fatal(err)
+	}
+	return
+}
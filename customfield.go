@@ -0,0 +1,9 @@
+package backlog
+
+// CustomField represents the value of the custom field set to the issue. The type of Value depends on FieldTypeId.
+type CustomField struct {
+	Id          int         `json:"id"`
+	FieldTypeId int         `json:"fieldTypeId"`
+	Name        string      `json:"name"`
+	Value       interface{} `json:"value"`
+}
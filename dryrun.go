@@ -0,0 +1,176 @@
+package backlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+)
+
+// DryRunRequest represents the mutating request recorded instead of being sent.
+type DryRunRequest struct {
+	Method string
+	Path   string
+	Values url.Values
+}
+
+type dryRun struct {
+	mu       sync.Mutex
+	enabled  bool
+	requests []DryRunRequest
+}
+
+// requiredValues lists the parameters which Backlog requires, so that the dry run fails in the same way as the real request.
+var requiredValues = []struct {
+	method  string
+	pattern string
+	keys    []string
+}{
+	{"POST", "issues", []string{"projectId", "summary", "issueTypeId", "priorityId"}},
+	{"POST", "issues/*/comments", []string{"content"}},
+	{"PATCH", "issues/*/comments/*", []string{"content"}},
+	{"POST", "projects/*/git/repositories/*/pullRequests", []string{"summary", "description", "base", "branch"}},
+	{"POST", "projects/*/git/repositories/*/pullRequests/*/comments", []string{"content"}},
+	{"POST", "projects/*/webhooks", []string{"name", "hookUrl"}},
+	{"POST", "wikis", []string{"projectId", "name", "content"}},
+	{"POST", "watchings", []string{"issueIdOrKey"}},
+}
+
+// listResponses lists the requests which Backlog answers with a list instead of a single object.
+var listResponses = []struct {
+	method  string
+	pattern string
+}{
+	{"POST", "wikis/*/attachments"},
+	{"POST", "wikis/*/sharedFiles"},
+}
+
+// SetDryRun enables or disables the dry run mode. In the dry run mode, POST, PATCH and DELETE requests are validated and recorded but not sent, and the methods return the zero value of the result, such as an empty Issue, with a nil error. The responses have the X-Dry-Run header, so that middlewares can tell them from the real ones, and DryRunRequests returns what would have been sent. GET requests are sent as usual. Disabling the dry run mode discards the recorded requests.
+func (c *Client) SetDryRun(enabled bool) {
+	c.dryRun.mu.Lock()
+	defer c.dryRun.mu.Unlock()
+
+	c.dryRun.enabled = enabled
+
+	if !enabled {
+		c.dryRun.requests = nil
+	}
+
+	return
+}
+
+// DryRunRequests returns the requests recorded in the dry run mode.
+func (c *Client) DryRunRequests() []DryRunRequest {
+	c.dryRun.mu.Lock()
+	defer c.dryRun.mu.Unlock()
+
+	return append([]DryRunRequest(nil), c.dryRun.requests...)
+}
+
+func (d *dryRun) isEnabled() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.enabled
+}
+
+func (d *dryRun) middleware(next Doer) Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodGet || !d.isEnabled() {
+			return next.Do(req)
+		}
+
+		values := url.Values{}
+
+		if strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+			if body := requestBody(req); len(body) > 0 {
+				parsed, err := url.ParseQuery(string(body))
+				if err != nil {
+					return nil, err
+				}
+				values = parsed
+			}
+		}
+		for key, value := range req.URL.Query() {
+			if key != "apiKey" {
+				values[key] = value
+			}
+		}
+
+		rel := RelativePath(req.URL.Path)
+
+		if err := validate(req.Method, rel, values); err != nil {
+			return dryRunResponse(req, http.StatusBadRequest, err), nil
+		}
+
+		d.mu.Lock()
+		d.requests = append(d.requests, DryRunRequest{
+			Method: req.Method,
+			Path:   rel,
+			Values: values,
+		})
+		d.mu.Unlock()
+
+		return dryRunResponse(req, http.StatusOK, nil), nil
+	})
+}
+
+func validate(method, rel string, values url.Values) error {
+	for _, required := range requiredValues {
+		if required.method != method {
+			continue
+		}
+		if ok, _ := path.Match(required.pattern, rel); !ok {
+			continue
+		}
+		for _, key := range required.keys {
+			if values.Get(key) == "" {
+				return Error{Message: fmt.Sprintf("Please input '%s'.", key), Code: 7}
+			}
+		}
+	}
+
+	return nil
+}
+
+func isListResponse(method, rel string) bool {
+	for _, list := range listResponses {
+		if list.method != method {
+			continue
+		}
+		if ok, _ := path.Match(list.pattern, rel); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// dryRunResponse returns the response marked with X-Dry-Run. The body is an empty object or list, which is decoded into the zero value of the result, or the error in the same form as Backlog.
+func dryRunResponse(req *http.Request, statusCode int, err error) *http.Response {
+	body := []byte("{}")
+
+	if isListResponse(req.Method, RelativePath(req.URL.Path)) {
+		body = []byte("[]")
+	}
+	if e, ok := err.(Error); ok {
+		body, _ = json.Marshal(Errors{Errors: []Error{e}})
+	}
+
+	return &http.Response{
+		Status:        http.StatusText(statusCode),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}, "X-Dry-Run": []string{"true"}},
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/moutend/go-backlog
+
+go 1.22
@@ -0,0 +1,211 @@
+// Command genmock generates the mocks of the services declared in services.go. It is run by go generate in the root of the module.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const header = `// Code generated by genmock from services.go. DO NOT EDIT.
+
+// Package backlogmock provides the mocks of the services of the backlog package. Each method calls the function in the field of the same name with the suffix Func, and panics if the field is nil.
+//
+//	issues := &backlogmock.IssueService{
+//		GetIssueFunc: func(issueId string) (*backlog.Issue, error) {
+//			return &backlog.Issue{IssueKey: issueId}, nil
+//		},
+//	}
+package backlogmock
+`
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("genmock: ")
+
+	input := flag.String("i", "services.go", "the file which declares the services")
+	output := flag.String("o", "backlogmock/backlogmock.go", "the file to write the mocks to")
+	flag.Parse()
+
+	if err := run(*input, *output); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(input, output string) error {
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, input, nil, 0)
+	if err != nil {
+		return err
+	}
+
+	// The packages referred from the services are imported by the mocks with the same paths.
+	paths := map[string]string{}
+
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		paths[path[strings.LastIndex(path, "/")+1:]] = path
+	}
+
+	var body bytes.Buffer
+	var embedded []string
+	imports := map[string]bool{}
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			iface, ok := typeSpec.Type.(*ast.InterfaceType)
+			if !ok {
+				continue
+			}
+
+			name := typeSpec.Name.Name
+			methods := []*ast.Field{}
+			embeds := []string{}
+
+			for _, field := range iface.Methods.List {
+				if len(field.Names) == 0 {
+					embeds = append(embeds, field.Type.(*ast.Ident).Name)
+				} else {
+					methods = append(methods, field)
+				}
+			}
+			if len(embeds) > 0 {
+				embedded = append(embedded, name)
+				writeComposite(&body, name, embeds)
+				continue
+			}
+
+			for _, method := range methods {
+				qualify(method.Type, paths, imports)
+			}
+
+			writeMock(fset, &body, name, methods)
+		}
+	}
+
+	var source bytes.Buffer
+	source.WriteString(header)
+	source.WriteString("\nimport (\n")
+
+	sorted := []string{}
+	for path := range imports {
+		sorted = append(sorted, path)
+	}
+	sort.Strings(sorted)
+
+	for _, path := range sorted {
+		fmt.Fprintf(&source, "\t%q\n", path)
+	}
+
+	source.WriteString("\n\tbacklog \"github.com/moutend/go-backlog\"\n)\n")
+	source.Write(body.Bytes())
+	source.WriteString("\nvar (\n")
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			name := spec.(*ast.TypeSpec).Name.Name
+			fmt.Fprintf(&source, "\t_ backlog.%s = (*%s)(nil)\n", name, name)
+		}
+	}
+
+	source.WriteString(")\n")
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return fmt.Errorf("%s: %w", output, err)
+	}
+
+	return ioutil.WriteFile(output, formatted, 0644)
+}
+
+// qualify rewrites the exported identifiers in the node as the ones of the backlog package, and records the packages referred.
+func qualify(node ast.Node, paths map[string]string, imports map[string]bool) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if x, ok := n.X.(*ast.Ident); ok && paths[x.Name] != "" {
+				imports[paths[x.Name]] = true
+			}
+			return false
+		case *ast.StarExpr:
+			n.X = qualifyExpr(n.X)
+		case *ast.ArrayType:
+			n.Elt = qualifyExpr(n.Elt)
+		case *ast.Field:
+			n.Type = qualifyExpr(n.Type)
+		}
+		return true
+	})
+}
+
+func qualifyExpr(expr ast.Expr) ast.Expr {
+	if ident, ok := expr.(*ast.Ident); ok && ast.IsExported(ident.Name) {
+		return &ast.SelectorExpr{X: ast.NewIdent("backlog"), Sel: ast.NewIdent(ident.Name)}
+	}
+
+	return expr
+}
+
+func writeMock(fset *token.FileSet, w *bytes.Buffer, name string, methods []*ast.Field) {
+	fmt.Fprintf(w, "\n// %s is the mock of backlog.%s.\ntype %s struct {\n", name, name, name)
+
+	for _, method := range methods {
+		fmt.Fprintf(w, "\t%sFunc func%s\n", method.Names[0].Name, signature(fset, method.Type.(*ast.FuncType)))
+	}
+
+	w.WriteString("}\n")
+
+	for _, method := range methods {
+		methodName := method.Names[0].Name
+		funcType := method.Type.(*ast.FuncType)
+		args := []string{}
+
+		for _, param := range funcType.Params.List {
+			for _, paramName := range param.Names {
+				args = append(args, paramName.Name)
+			}
+		}
+
+		fmt.Fprintf(w, "\nfunc (m *%s) %s%s {\n", name, methodName, signature(fset, funcType))
+		fmt.Fprintf(w, "\tif m.%sFunc == nil {\n\t\tpanic(\"backlogmock: %s.%sFunc is not set\")\n\t}\n\n", methodName, name, methodName)
+		fmt.Fprintf(w, "\treturn m.%sFunc(%s)\n}\n", methodName, strings.Join(args, ", "))
+	}
+}
+
+func writeComposite(w *bytes.Buffer, name string, embeds []string) {
+	fmt.Fprintf(w, "\n// %s is the mock of backlog.%s, which consists of the mocks of the services.\ntype %s struct {\n", name, name, name)
+
+	for _, embed := range embeds {
+		fmt.Fprintf(w, "\t%s\n", embed)
+	}
+
+	w.WriteString("}\n")
+}
+
+// signature returns the parameters and the results of the function type.
+func signature(fset *token.FileSet, funcType *ast.FuncType) string {
+	var b bytes.Buffer
+
+	format.Node(&b, fset, funcType)
+
+	return strings.TrimPrefix(b.String(), "func")
+}
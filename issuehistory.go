@@ -0,0 +1,174 @@
+package backlog
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IssueChange represents a single field change recorded in the comment of the issue.
+type IssueChange struct {
+	CommentId int
+	User      User
+	Time      time.Time
+	ChangeLog ChangeLog
+}
+
+// IssueHistory represents the issue and the chronological list of the changes made to it.
+type IssueHistory struct {
+	Issue   *Issue
+	Changes []IssueChange
+}
+
+// IssueState represents the values of the issue fields at a point in time. The values are formatted the same way as ChangeLog.NewValue and ChangeLog.OriginalValue.
+type IssueState struct {
+	Fields       map[ChangeLogField]string
+	CustomFields map[int]string
+}
+
+// stateFields lists the fields which hold a value. The other fields such as attachment and notification are recorded as events. The parent issue is not included because Issue has only its id, which cannot be compared with the values in the change logs, so its changes are available only from ChangesOf.
+var stateFields = map[ChangeLogField]bool{
+	ChangeLogFieldSummary:        true,
+	ChangeLogFieldDescription:    true,
+	ChangeLogFieldCategory:       true,
+	ChangeLogFieldVersion:        true,
+	ChangeLogFieldMilestone:      true,
+	ChangeLogFieldStatus:         true,
+	ChangeLogFieldAssigner:       true,
+	ChangeLogFieldIssueType:      true,
+	ChangeLogFieldStartDate:      true,
+	ChangeLogFieldDueDate:        true,
+	ChangeLogFieldPriority:       true,
+	ChangeLogFieldResolution:     true,
+	ChangeLogFieldEstimatedHours: true,
+	ChangeLogFieldActualHours:    true,
+}
+
+func newIssueHistory(issue *Issue, comments []*Comment) *IssueHistory {
+	history := &IssueHistory{
+		Issue: issue,
+	}
+
+	for _, comment := range comments {
+		for _, changeLog := range comment.ChangeLog {
+			history.Changes = append(history.Changes, IssueChange{
+				CommentId: comment.Id,
+				User:      comment.CreatedUser,
+				Time:      comment.Created.Time(),
+				ChangeLog: changeLog,
+			})
+		}
+	}
+
+	sort.SliceStable(history.Changes, func(i, j int) bool {
+		return history.Changes[i].Time.Before(history.Changes[j].Time)
+	})
+
+	return history
+}
+
+// At returns the state of the issue at t. Custom fields which have never been changed are not included because the current value is not known.
+func (h *IssueHistory) At(t time.Time) *IssueState {
+	state := &IssueState{
+		Fields:       currentIssueFields(h.Issue),
+		CustomFields: map[int]string{},
+	}
+
+	for _, change := range h.Changes {
+		if change.Time.After(t) {
+			break
+		}
+		state.set(change.ChangeLog, change.ChangeLog.NewValue)
+	}
+	for i := len(h.Changes) - 1; i >= 0; i-- {
+		change := h.Changes[i]
+
+		if !change.Time.After(t) {
+			break
+		}
+		state.set(change.ChangeLog, change.ChangeLog.OriginalValue)
+	}
+
+	return state
+}
+
+// ChangesOf returns the changes made to the field.
+func (h *IssueHistory) ChangesOf(field ChangeLogField) []IssueChange {
+	var changes []IssueChange
+
+	for _, change := range h.Changes {
+		if change.ChangeLog.Field == field {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+func (s *IssueState) set(changeLog ChangeLog, value string) {
+	if changeLog.IsCustomField() {
+		s.CustomFields[changeLog.CustomFieldId()] = value
+		return
+	}
+	if stateFields[changeLog.Field] {
+		s.Fields[changeLog.Field] = value
+	}
+}
+
+func currentIssueFields(issue *Issue) map[ChangeLogField]string {
+	fields := map[ChangeLogField]string{}
+
+	if issue == nil {
+		return fields
+	}
+
+	fields[ChangeLogFieldSummary] = issue.Summary
+	fields[ChangeLogFieldDescription] = issue.Description
+	fields[ChangeLogFieldStatus] = issue.Status.Name
+	fields[ChangeLogFieldAssigner] = issue.Assignee.Name
+	fields[ChangeLogFieldIssueType] = issue.IssueType.Name
+	fields[ChangeLogFieldPriority] = issue.Priority.Name
+	fields[ChangeLogFieldResolution] = issue.Resolution.Name
+	fields[ChangeLogFieldStartDate] = formatChangeLogDate(issue.StartDate)
+	fields[ChangeLogFieldDueDate] = formatChangeLogDate(issue.DueDate)
+	fields[ChangeLogFieldEstimatedHours] = formatChangeLogHours(issue.EstimatedHours)
+	fields[ChangeLogFieldActualHours] = formatChangeLogHours(issue.ActualHours)
+
+	var names []string
+
+	for _, category := range issue.Category {
+		names = append(names, category.Name)
+	}
+	fields[ChangeLogFieldCategory] = strings.Join(names, ", ")
+
+	names = nil
+	for _, version := range issue.Versions {
+		names = append(names, version.Name)
+	}
+	fields[ChangeLogFieldVersion] = strings.Join(names, ", ")
+
+	names = nil
+	for _, milestone := range issue.Milestone {
+		names = append(names, milestone.Name)
+	}
+	fields[ChangeLogFieldMilestone] = strings.Join(names, ", ")
+
+	return fields
+}
+
+func formatChangeLogDate(d Date) string {
+	if d == "" {
+		return ""
+	}
+
+	return d.Time().Format("2006-01-02")
+}
+
+func formatChangeLogHours(hours float64) string {
+	if hours == 0 {
+		return ""
+	}
+
+	return strconv.FormatFloat(hours, 'f', -1, 64)
+}
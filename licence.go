@@ -0,0 +1,37 @@
+package backlog
+
+type Licence struct {
+	Active                            bool  `json:"active"`
+	AttachmentLimit                   int64 `json:"attachmentLimit"`
+	AttachmentLimitPerFile            int64 `json:"attachmentLimitPerFile"`
+	AttachmentNumLimit                int   `json:"attachmentNumLimit"`
+	Attribute                         bool  `json:"attribute"`
+	AttributeLimit                    int   `json:"attributeLimit"`
+	Burndown                          bool  `json:"burndown"`
+	CommentLimit                      int   `json:"commentLimit"`
+	ComponentLimit                    int   `json:"componentLimit"`
+	FileSharing                       bool  `json:"fileSharing"`
+	Gantt                             bool  `json:"gantt"`
+	Git                               bool  `json:"git"`
+	IssueLimit                        int   `json:"issueLimit"`
+	LicenceTypeId                     int   `json:"licenceTypeId"`
+	LimitDate                         Date  `json:"limitDate"`
+	NulabAccount                      bool  `json:"nulabAccount"`
+	ParentChild                       bool  `json:"parentChild"`
+	PostIssueByMail                   bool  `json:"postIssueByMail"`
+	ProjectGroup                      bool  `json:"projectGroup"`
+	ProjectLimit                      int   `json:"projectLimit"`
+	PullRequestAttachmentLimitPerFile int64 `json:"pullRequestAttachmentLimitPerFile"`
+	PullRequestAttachmentNumLimit     int   `json:"pullRequestAttachmentNumLimit"`
+	RemoteAddress                     bool  `json:"remoteAddress"`
+	RemoteAddressLimit                int   `json:"remoteAddressLimit"`
+	StartedOn                         Date  `json:"startedOn"`
+	StorageLimit                      int64 `json:"storageLimit"`
+	Subversion                        bool  `json:"subversion"`
+	SubversionExternal                bool  `json:"subversionExternal"`
+	UserLimit                         int   `json:"userLimit"`
+	VersionLimit                      int   `json:"versionLimit"`
+	WikiAttachment                    bool  `json:"wikiAttachment"`
+	WikiAttachmentLimitPerFile        int64 `json:"wikiAttachmentLimitPerFile"`
+	WikiAttachmentNumLimit            int   `json:"wikiAttachmentNumLimit"`
+}
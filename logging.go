@@ -0,0 +1,248 @@
+package backlog
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+)
+
+// DefaultMaxBodyBytes is the number of bytes logged for each body when LogOptions.MaxBodyBytes is zero.
+const DefaultMaxBodyBytes = 1024
+
+// LogOptions controls what is logged for each request.
+type LogOptions struct {
+	// Bodies enables logging the request and response bodies at the debug level.
+	Bodies bool
+	// MaxBodyBytes truncates the logged bodies. DefaultMaxBodyBytes is used if it is zero.
+	MaxBodyBytes int
+}
+
+// secretKeys lists the parameters whose values are never logged.
+var secretKeys = []string{
+	"apiKey",
+	"access_token",
+	"refresh_token",
+	"client_secret",
+	"code",
+}
+
+const redacted = "REDACTED"
+
+var requestId uint64
+
+// SetLogHandler sends the log to the handler. Each request is logged with the request id, method and path. The query, form values and bodies are logged at the debug level, and the status, latency and byte counts are logged at the info level. The API key and OAuth tokens are always redacted.
+func (c *Client) SetLogHandler(handler slog.Handler, options *LogOptions) {
+	c.logger = slog.New(handler)
+	c.logOptions = LogOptions{}
+
+	if options != nil {
+		c.logOptions = *options
+	}
+
+	return
+}
+
+// LoggingMiddleware returns the middleware which logs the requests in the same way as SetLogHandler.
+func LoggingMiddleware(logger *slog.Logger, options *LogOptions) Middleware {
+	if options == nil {
+		options = &LogOptions{}
+	}
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			ctx := req.Context()
+			body := requestBody(req)
+
+			logger := logger.With(
+				slog.Uint64("requestId", atomic.AddUint64(&requestId, 1)),
+				slog.String("method", req.Method),
+				slog.String("path", req.URL.Path))
+
+			logger.DebugContext(ctx, "request",
+				slog.String("query", redactQuery(req.URL.Query())),
+				slog.Int("bytes", len(body)),
+				logBody(options, req.Header.Get("Content-Type"), body))
+
+			started := time.Now()
+
+			res, err := next.Do(req)
+			if err != nil {
+				logger.WarnContext(ctx, "request failed",
+					slog.Duration("latency", time.Since(started)),
+					slog.String("error", redactError(err).Error()))
+				return nil, err
+			}
+
+			response, err := ioutil.ReadAll(res.Body)
+			res.Body.Close()
+			res.Body = ioutil.NopCloser(bytes.NewReader(response))
+
+			if err != nil {
+				return nil, err
+			}
+
+			level := slog.LevelInfo
+
+			if res.StatusCode >= 400 {
+				level = slog.LevelWarn
+			}
+
+			logger.Log(ctx, level, "response",
+				slog.Int("status", res.StatusCode),
+				slog.Duration("latency", time.Since(started)),
+				slog.Int("bytes", len(response)))
+
+			if options.Bodies && len(response) > 0 {
+				logger.DebugContext(ctx, "response body",
+					logBody(options, res.Header.Get("Content-Type"), response))
+			}
+
+			return res, nil
+		})
+	}
+}
+
+// requestBody returns the copy of the request body, leaving the request untouched.
+func requestBody(req *http.Request) []byte {
+	if req.GetBody == nil {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+
+	data, _ := ioutil.ReadAll(body)
+
+	return data
+}
+
+func logBody(options *LogOptions, contentType string, body []byte) slog.Attr {
+	if !options.Bodies || len(body) == 0 {
+		return slog.Attr{}
+	}
+
+	max := options.MaxBodyBytes
+
+	if max <= 0 {
+		max = DefaultMaxBodyBytes
+	}
+
+	s := string(body)
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		if values, err := url.ParseQuery(s); err == nil {
+			s = redactQuery(values)
+		}
+	}
+	if len(s) > max {
+		// The body is cut on the rune boundary so that the log stays valid UTF-8.
+		for max > 0 && !utf8.RuneStart(s[max]) {
+			max--
+		}
+		s = s[:max] + "...(truncated)"
+	}
+
+	return slog.String("body", s)
+}
+
+func redactQuery(query url.Values) string {
+	redactedQuery := url.Values{}
+
+	for key, values := range query {
+		redactedQuery[key] = values
+
+		if isSecretKey(key) {
+			redactedQuery[key] = []string{redacted}
+		}
+	}
+
+	return redactedQuery.Encode()
+}
+
+func isSecretKey(key string) bool {
+	for _, secretKey := range secretKeys {
+		if strings.EqualFold(key, secretKey) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactError removes the secrets from the error, since *url.Error contains the URL with the query.
+func redactError(err error) error {
+	urlError, ok := err.(*url.Error)
+	if !ok {
+		return err
+	}
+
+	u, parseErr := url.Parse(urlError.URL)
+	if parseErr != nil {
+		return &url.Error{Op: urlError.Op, Err: urlError.Err}
+	}
+
+	u.RawQuery = redactQuery(u.Query())
+
+	return &url.Error{Op: urlError.Op, URL: u.String(), Err: urlError.Err}
+}
+
+// stdLogHandler writes the records to *log.Logger in the text format of slog without the time, which is added by the logger according to its flags.
+type stdLogHandler struct {
+	logger *log.Logger
+	wraps  []func(slog.Handler) slog.Handler
+}
+
+func (h *stdLogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return true
+}
+
+func (h *stdLogHandler) Handle(ctx context.Context, record slog.Record) error {
+	var buffer bytes.Buffer
+	var handler slog.Handler = slog.NewTextHandler(&buffer, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+
+	for _, wrap := range h.wraps {
+		handler = wrap(handler)
+	}
+	if err := handler.Handle(ctx, record); err != nil {
+		return err
+	}
+
+	return h.logger.Output(2, strings.TrimSuffix(buffer.String(), "\n"))
+}
+
+func (h *stdLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler {
+		return handler.WithAttrs(attrs)
+	})
+}
+
+func (h *stdLogHandler) WithGroup(name string) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler {
+		return handler.WithGroup(name)
+	})
+}
+
+func (h *stdLogHandler) with(wrap func(slog.Handler) slog.Handler) slog.Handler {
+	wraps := append(append([]func(slog.Handler) slog.Handler(nil), h.wraps...), wrap)
+
+	return &stdLogHandler{logger: h.logger, wraps: wraps}
+}
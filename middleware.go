@@ -0,0 +1,58 @@
+package backlog
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Doer sends the HTTP request. *http.Client implements Doer.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is an adapter to allow the use of ordinary functions as Doer.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the Doer to inspect or modify the requests and responses.
+type Middleware func(next Doer) Doer
+
+// Use adds the middlewares to the client. The middleware added first sees the request first and the response last. The logging configured with SetLogHandler is always the innermost, so that each attempt made by the middlewares is logged.
+func (c *Client) Use(middlewares ...Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+
+	return
+}
+
+// SetHTTPClient replaces the Doer which finally sends the requests. The default is &http.Client{}.
+func (c *Client) SetHTTPClient(httpClient Doer) {
+	c.httpClient = httpClient
+
+	return
+}
+
+func (c *Client) doer() Doer {
+	doer := c.httpClient
+
+	doer = c.dryRun.middleware(doer)
+
+	doer = LoggingMiddleware(c.logger, &c.logOptions)(doer)
+
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		doer = c.middlewares[i](doer)
+	}
+
+	return doer
+}
+
+// RelativePath returns the path relative to "/api/v2/", such as "issues/SAMPLE-1". Middlewares can use it to identify the resource of the request.
+func RelativePath(p string) string {
+	if i := strings.Index(p, "/api/v2/"); i >= 0 {
+		return strings.Trim(p[i+len("/api/v2/"):], "/")
+	}
+
+	return strings.Trim(p, "/")
+}
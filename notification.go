@@ -1,8 +1,62 @@
 package backlog
 
 type Notification struct {
-	Id          int  `json:"id"`
-	AlreadyRead bool `json:"alreadyRead"`
-	Reason      int  `json:"reason"`
-	User        User `json:"user"`
+	Id                  int                 `json:"id"`
+	AlreadyRead         bool                `json:"alreadyRead"`
+	Reason              NotificationReason  `json:"reason"`
+	User                User                `json:"user"`
+	ResourceAlreadyRead bool                `json:"resourceAlreadyRead"`
+	Project             *Project            `json:"project"`
+	Issue               *Issue              `json:"issue"`
+	Comment             *Comment            `json:"comment"`
+	PullRequest         *PullRequest        `json:"pullRequest"`
+	PullRequestComment  *PullRequestComment `json:"pullRequestComment"`
+	Sender              User                `json:"sender"`
+	Created             Date                `json:"created"`
+}
+
+// NotificationReason represents why the notification was sent.
+type NotificationReason int
+
+const (
+	NotificationReasonAssigned             NotificationReason = 1
+	NotificationReasonCommented            NotificationReason = 2
+	NotificationReasonIssueCreated         NotificationReason = 3
+	NotificationReasonIssueUpdated         NotificationReason = 4
+	NotificationReasonFileAttached         NotificationReason = 5
+	NotificationReasonProjectUserAdded     NotificationReason = 6
+	NotificationReasonOther                NotificationReason = 9
+	NotificationReasonPullRequestAssigned  NotificationReason = 10
+	NotificationReasonPullRequestCommented NotificationReason = 11
+	NotificationReasonPullRequestAdded     NotificationReason = 12
+	NotificationReasonPullRequestUpdated   NotificationReason = 13
+)
+
+func (r NotificationReason) String() string {
+	switch r {
+	case NotificationReasonAssigned:
+		return "Assigned"
+	case NotificationReasonCommented:
+		return "Commented"
+	case NotificationReasonIssueCreated:
+		return "IssueCreated"
+	case NotificationReasonIssueUpdated:
+		return "IssueUpdated"
+	case NotificationReasonFileAttached:
+		return "FileAttached"
+	case NotificationReasonProjectUserAdded:
+		return "ProjectUserAdded"
+	case NotificationReasonOther:
+		return "Other"
+	case NotificationReasonPullRequestAssigned:
+		return "PullRequestAssigned"
+	case NotificationReasonPullRequestCommented:
+		return "PullRequestCommented"
+	case NotificationReasonPullRequestAdded:
+		return "PullRequestAdded"
+	case NotificationReasonPullRequestUpdated:
+		return "PullRequestUpdated"
+	default:
+		return "Unknown"
+	}
 }
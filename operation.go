@@ -0,0 +1,21 @@
+package backlog
+
+import "context"
+
+type operationKey struct{}
+
+// OperationName returns the name of the Client method which issued the request, such as "GetIssues". Middlewares can call it with the context of the request.
+func OperationName(ctx context.Context) string {
+	name, _ := ctx.Value(operationKey{}).(string)
+
+	return name
+}
+
+// withOperation stores the name of the Client method in the context, unless it has been stored by the outer call. The name is given without the Context suffix.
+func withOperation(ctx context.Context, name string) context.Context {
+	if OperationName(ctx) != "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, operationKey{}, name)
+}
@@ -0,0 +1,163 @@
+// Package otelbacklog instruments backlog.Client with OpenTelemetry.
+//
+// The middleware starts a client span named after the Client method, such as "backlog.GetIssues", and records the number of requests, the number of errors by Backlog error code, and the latency. The metrics are recorded by operation, method, status code and error code.
+//
+//	mw, err := otelbacklog.Middleware(nil, nil)
+//	if err != nil {
+//		return err
+//	}
+//	client.Use(mw)
+//
+// The package is a separate module, so that the users of backlog do not depend on OpenTelemetry.
+package otelbacklog
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	backlog "github.com/moutend/go-backlog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/moutend/go-backlog/otelbacklog"
+
+const (
+	OperationKey  = attribute.Key("backlog.operation")
+	ProjectKey    = attribute.Key("backlog.project.key")
+	IssueKey      = attribute.Key("backlog.issue.key")
+	ErrorCodeKey  = attribute.Key("backlog.error.code")
+	MethodKey     = attribute.Key("http.request.method")
+	StatusCodeKey = attribute.Key("http.response.status_code")
+)
+
+// Middleware returns the middleware which instruments the requests. The global providers are used if tracerProvider or meterProvider is nil.
+func Middleware(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) (backlog.Middleware, error) {
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	if meterProvider == nil {
+		meterProvider = otel.GetMeterProvider()
+	}
+
+	tracer := tracerProvider.Tracer(instrumentationName)
+	meter := meterProvider.Meter(instrumentationName)
+
+	requests, err := meter.Int64Counter("backlog.client.requests",
+		metric.WithDescription("The number of requests sent to Backlog."))
+	if err != nil {
+		return nil, err
+	}
+
+	errors, err := meter.Int64Counter("backlog.client.errors",
+		metric.WithDescription("The number of requests which failed, by Backlog error code."))
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := meter.Float64Histogram("backlog.client.duration",
+		metric.WithDescription("The latency of the requests sent to Backlog."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next backlog.Doer) backlog.Doer {
+		return backlog.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			operation := backlog.OperationName(req.Context())
+
+			// The metrics are recorded with the bounded attributes only, and the keys of the resources are attached to the span.
+			attrs := []attribute.KeyValue{
+				OperationKey.String(operation),
+				MethodKey.String(req.Method),
+			}
+
+			ctx, span := tracer.Start(req.Context(), "backlog."+operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+				trace.WithAttributes(resourceAttributes(req)...))
+			defer span.End()
+
+			started := time.Now()
+			res, err := next.Do(req.WithContext(ctx))
+
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+				requests.Add(ctx, 1, metric.WithAttributes(attrs...))
+				duration.Record(ctx, time.Since(started).Seconds(), metric.WithAttributes(attrs...))
+				return nil, err
+			}
+
+			attrs = append(attrs, StatusCodeKey.Int(res.StatusCode))
+			span.SetAttributes(StatusCodeKey.Int(res.StatusCode))
+
+			if res.StatusCode >= 400 {
+				code := errorCode(res)
+				attrs = append(attrs, ErrorCodeKey.Int(code))
+				span.SetAttributes(ErrorCodeKey.Int(code))
+				span.SetStatus(codes.Error, http.StatusText(res.StatusCode))
+				errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+			}
+
+			requests.Add(ctx, 1, metric.WithAttributes(attrs...))
+			duration.Record(ctx, time.Since(started).Seconds(), metric.WithAttributes(attrs...))
+
+			return res, nil
+		})
+	}, nil
+}
+
+// resourceAttributes extracts the project key and the issue key from the path and the query, such as "/api/v2/issues/SAMPLE-1/comments". They are attached to the span only, since they would make the metrics unbounded.
+func resourceAttributes(req *http.Request) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	var project string
+
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+
+	for i := 0; i+1 < len(segments); i++ {
+		switch segments[i] {
+		case "projects":
+			project = segments[i+1]
+		case "issues":
+			if segments[i+1] != "count" {
+				attrs = append(attrs, IssueKey.String(segments[i+1]))
+			}
+		}
+	}
+	if project == "" {
+		project = req.URL.Query().Get("projectIdOrKey")
+	}
+	if project != "" {
+		attrs = append(attrs, ProjectKey.String(project))
+	}
+
+	return attrs
+}
+
+// errorCode returns the code of the first error in the response body, leaving the body readable by the caller.
+func errorCode(res *http.Response) int {
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	if err != nil {
+		return 0
+	}
+
+	var errors backlog.Errors
+
+	if json.Unmarshal(body, &errors) != nil || len(errors.Errors) == 0 {
+		return 0
+	}
+
+	return errors.Errors[0].Code
+}
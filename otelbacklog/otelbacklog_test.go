@@ -0,0 +1,91 @@
+package otelbacklog
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	backlog "github.com/moutend/go-backlog"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestMiddleware(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	mw, err := Middleware(tracerProvider, meterProvider)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, _ := backlog.New("spaceName", "XXXXXXXX")
+	client.SetHTTPClient(backlog.DoerFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader(`{"errors":[{"message":"No issue.","code":6,"moreInfo":""}]}`)),
+		}, nil
+	}))
+	client.Use(mw)
+
+	if _, err = client.GetIssue("SAMPLE-1"); err == nil {
+		t.Fatal("expected error")
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Name != "backlog.GetIssue" {
+		t.Fatalf("expected backlog.GetIssue, got %v", spans[0].Name)
+	}
+
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range spans[0].Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	if attrs[IssueKey].AsString() != "SAMPLE-1" {
+		t.Fatalf("unexpected issue key %v", attrs[IssueKey])
+	}
+	if attrs[ErrorCodeKey].AsInt64() != 6 {
+		t.Fatalf("unexpected error code %v", attrs[ErrorCodeKey])
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err = reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	names := map[string]bool{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			names[m.Name] = true
+
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				continue
+			}
+			for _, dp := range sum.DataPoints {
+				if _, ok := dp.Attributes.Value(IssueKey); ok {
+					t.Errorf("%s is recorded with the issue key", m.Name)
+				}
+				if code, _ := dp.Attributes.Value(ErrorCodeKey); code.AsInt64() != 6 {
+					t.Errorf("%s is recorded without the error code", m.Name)
+				}
+			}
+		}
+	}
+	for _, name := range []string{"backlog.client.requests", "backlog.client.errors", "backlog.client.duration"} {
+		if !names[name] {
+			t.Errorf("%s is not recorded", name)
+		}
+	}
+}
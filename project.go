@@ -1,12 +1,15 @@
 package backlog
 
 type Project struct {
-	Id                                int    `json:"id"`
-	ProjectKey                        string `json:"projectKey"`
-	Name                              string `json:"name"`
-	ChartEnabled                      bool   `json:"chartEnabled"`
-	SubtaskingEnabled                 bool   `json:"subtaskingEnabled"`
-	ProjectLeaderCanEditProjectLeader bool   `json:"projectLeaderCanEditProjectLeader"`
-	TextFormattingRule                string `json:"textFormattingRule"`
-	Archived                          bool   `json:"archived"`
+	Id                                int                `json:"id"`
+	ProjectKey                        string             `json:"projectKey"`
+	Name                              string             `json:"name"`
+	ChartEnabled                      bool               `json:"chartEnabled"`
+	SubtaskingEnabled                 bool               `json:"subtaskingEnabled"`
+	ProjectLeaderCanEditProjectLeader bool               `json:"projectLeaderCanEditProjectLeader"`
+	TextFormattingRule                TextFormattingRule `json:"textFormattingRule"`
+	Archived                          bool               `json:"archived"`
+	UseWiki                           bool               `json:"useWiki"`
+	UseFileSharing                    bool               `json:"useFileSharing"`
+	UseDevAttributes                  bool               `json:"useDevAttributes"`
 }
@@ -1,23 +1,46 @@
 package backlog
 
+import "time"
+
 type PullRequest struct {
-	Id           int     `json:"id"`
-	ProjectId    int     `json:"projectId"`
-	RepositoryID int     `json:"repositoryID"`
-	Number       int     `json:"number"`
-	Summary      string  `json:"summary"`
-	Description  string  `json:"description"`
-	Base         string  `json:"base"`
-	Branch       string  `json:"branch"`
-	Status       Status  `json:"status"`
-	Assignee     User    `json:"assignee"`
-	Issue        Issue   `json:"issue"`
-	BaseCommit   string  `json:"baseCommit"`
-	BranchCommit string  `json:"branchCommit"`
-	CloseAt      *string `json:"closeAt"`
-	MergeAt      string  `json:"mergeAt"`
-	CreateUser   User    `json:"createUser"`
-	Created      string  `json:"created"`
-	UpdateUser   User    `json:"updateUser"`
-	Update       string  `json:"update"`
+	Id           int               `json:"id"`
+	ProjectId    int               `json:"projectId"`
+	RepositoryId int               `json:"repositoryId"`
+	Number       int               `json:"number"`
+	Summary      string            `json:"summary"`
+	Description  string            `json:"description"`
+	Base         string            `json:"base"`
+	Branch       string            `json:"branch"`
+	Status       PullRequestStatus `json:"status"`
+	Assignee     User              `json:"assignee"`
+	Issue        Issue             `json:"issue"`
+	BaseCommit   string            `json:"baseCommit"`
+	BranchCommit string            `json:"branchCommit"`
+	CloseAt      Date              `json:"closeAt"`
+	MergeAt      Date              `json:"mergeAt"`
+	CreatedUser  User              `json:"createdUser"`
+	Created      Date              `json:"created"`
+	UpdatedUser  User              `json:"updatedUser"`
+	Updated      Date              `json:"updated"`
+}
+
+func (p *PullRequest) IsOpen() bool {
+	return p.Status == PullRequestStatusOpen
+}
+
+func (p *PullRequest) IsClosed() bool {
+	return p.Status == PullRequestStatusClosed
+}
+
+func (p *PullRequest) IsMerged() bool {
+	return p.Status == PullRequestStatusMerged
+}
+
+// TimeToMerge returns the duration from the creation to the merge of the pull request. It returns 0 if the pull request has not been merged.
+func (p *PullRequest) TimeToMerge() time.Duration {
+	if !p.IsMerged() || p.MergeAt == "" {
+		return 0
+	}
+
+	return p.MergeAt.Time().Sub(p.Created.Time())
 }
@@ -0,0 +1,114 @@
+package backlog
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// CreatePullRequestInput represents the parameters used to create the pull request. Summary, Description, Base and Branch are required.
+type CreatePullRequestInput struct {
+	Summary         string
+	Description     string
+	Base            string
+	Branch          string
+	IssueId         int
+	AssigneeId      int
+	NotifiedUserIds []int
+	AttachmentIds   []int
+}
+
+// Values returns the form values passed to CreatePullRequest.
+func (i *CreatePullRequestInput) Values() url.Values {
+	values := url.Values{}
+	values.Set("summary", i.Summary)
+	values.Set("description", i.Description)
+	values.Set("base", i.Base)
+	values.Set("branch", i.Branch)
+
+	if i.IssueId != 0 {
+		values.Set("issueId", strconv.Itoa(i.IssueId))
+	}
+	if i.AssigneeId != 0 {
+		values.Set("assigneeId", strconv.Itoa(i.AssigneeId))
+	}
+
+	addInts(values, "notifiedUserId[]", i.NotifiedUserIds)
+	addInts(values, "attachmentId[]", i.AttachmentIds)
+
+	return values
+}
+
+// UpdatePullRequestInput represents the parameters used to update the pull request. The zero values are left unchanged.
+type UpdatePullRequestInput struct {
+	Summary         string
+	Description     string
+	IssueId         int
+	AssigneeId      int
+	NotifiedUserIds []int
+	AttachmentIds   []int
+	Comment         string
+}
+
+// Values returns the form values passed to UpdatePullRequest.
+func (i *UpdatePullRequestInput) Values() url.Values {
+	values := url.Values{}
+
+	if i.Summary != "" {
+		values.Set("summary", i.Summary)
+	}
+	if i.Description != "" {
+		values.Set("description", i.Description)
+	}
+	if i.IssueId != 0 {
+		values.Set("issueId", strconv.Itoa(i.IssueId))
+	}
+	if i.AssigneeId != 0 {
+		values.Set("assigneeId", strconv.Itoa(i.AssigneeId))
+	}
+	if i.Comment != "" {
+		values.Set("comment", i.Comment)
+	}
+
+	addInts(values, "notifiedUserId[]", i.NotifiedUserIds)
+	addInts(values, "attachmentId[]", i.AttachmentIds)
+
+	return values
+}
+
+// PullRequestQuery represents the filters used to list the pull requests. The empty filters are ignored.
+type PullRequestQuery struct {
+	Statuses       []PullRequestStatus
+	AssigneeIds    []int
+	IssueIds       []int
+	CreatedUserIds []int
+	Offset         int
+	Count          int
+}
+
+// Values returns the query passed to GetPullRequests and GetPullRequestsCount.
+func (q *PullRequestQuery) Values() url.Values {
+	values := url.Values{}
+
+	for _, status := range q.Statuses {
+		values.Add("statusId[]", strconv.Itoa(int(status)))
+	}
+
+	addInts(values, "assigneeId[]", q.AssigneeIds)
+	addInts(values, "issueId[]", q.IssueIds)
+	addInts(values, "createdUserId[]", q.CreatedUserIds)
+
+	if q.Offset != 0 {
+		values.Set("offset", strconv.Itoa(q.Offset))
+	}
+	if q.Count != 0 {
+		values.Set("count", strconv.Itoa(q.Count))
+	}
+
+	return values
+}
+
+func addInts(values url.Values, key string, ints []int) {
+	for _, i := range ints {
+		values.Add(key, strconv.Itoa(i))
+	}
+}
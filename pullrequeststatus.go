@@ -0,0 +1,46 @@
+package backlog
+
+import "encoding/json"
+
+// PullRequestStatus represents the status of the pull request. It is encoded as the object which has id and name in JSON.
+type PullRequestStatus int
+
+const (
+	PullRequestStatusOpen   PullRequestStatus = 1
+	PullRequestStatusClosed PullRequestStatus = 2
+	PullRequestStatusMerged PullRequestStatus = 3
+)
+
+func (s PullRequestStatus) String() string {
+	switch s {
+	case PullRequestStatusOpen:
+		return "Open"
+	case PullRequestStatusClosed:
+		return "Closed"
+	case PullRequestStatusMerged:
+		return "Merged"
+	default:
+		return "Unknown"
+	}
+}
+
+func (s PullRequestStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Id   int    `json:"id"`
+		Name string `json:"name"`
+	}{int(s), s.String()})
+}
+
+func (s *PullRequestStatus) UnmarshalJSON(data []byte) error {
+	var status struct {
+		Id int `json:"id"`
+	}
+
+	if err := json.Unmarshal(data, &status); err != nil {
+		return err
+	}
+
+	*s = PullRequestStatus(status.Id)
+
+	return nil
+}
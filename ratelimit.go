@@ -0,0 +1,86 @@
+package backlog
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit represents the rate limits of the API. Each kind of request is limited separately.
+type RateLimit struct {
+	Read   RateLimitBucket `json:"read"`
+	Update RateLimitBucket `json:"update"`
+	Search RateLimitBucket `json:"search"`
+	Icon   RateLimitBucket `json:"icon"`
+}
+
+type RateLimitBucket struct {
+	Limit     int   `json:"limit"`
+	Remaining int   `json:"remaining"`
+	Reset     int64 `json:"reset"`
+}
+
+// ResetTime returns the time when Remaining is reset to Limit.
+func (b RateLimitBucket) ResetTime() time.Time {
+	return time.Unix(b.Reset, 0)
+}
+
+// RateLimitMiddleware returns the middleware which holds the requests back until X-RateLimit-Reset once X-RateLimit-Remaining reaches zero. GET requests and the other methods are tracked separately, which roughly corresponds to the read and update limits.
+func RateLimitMiddleware() Middleware {
+	var mu sync.Mutex
+
+	buckets := map[bool]*rateLimitState{
+		true:  {},
+		false: {},
+	}
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			bucket := buckets[req.Method == http.MethodGet]
+			delay := bucket.delay()
+			mu.Unlock()
+
+			if err := sleep(req.Context(), delay); err != nil {
+				return nil, err
+			}
+
+			res, err := next.Do(req)
+			if err != nil {
+				return nil, err
+			}
+
+			mu.Lock()
+			bucket.update(res)
+			mu.Unlock()
+
+			return res, nil
+		})
+	}
+}
+
+type rateLimitState struct {
+	known     bool
+	remaining int
+	reset     time.Time
+}
+
+func (s *rateLimitState) delay() time.Duration {
+	if !s.known || s.remaining > 0 {
+		return 0
+	}
+
+	return time.Until(s.reset)
+}
+
+func (s *rateLimitState) update(res *http.Response) {
+	remaining, err := strconv.Atoi(res.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	s.known = true
+	s.remaining = remaining
+	s.reset = rateLimitReset(res)
+}
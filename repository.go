@@ -1,5 +1,10 @@
 package backlog
 
+import (
+	"net/url"
+	"strings"
+)
+
 type Repository struct {
 	Id           int     `json:"id"`
 	ProjectId    int     `json:"projectId"`
@@ -9,9 +14,44 @@ type Repository struct {
 	HTTPURL      string  `json:"httpUrl"`
 	SSHURL       string  `json:"sshUrl"`
 	DisplayOrder int     `json:"displayOrder"`
-	PushedAt     *string `json:"pushedAt"`
+	PushedAt     Date    `json:"pushedAt"`
 	CreatedUser  User    `json:"createdUser"`
-	Created      string  `json:"created"`
-	UpdatedUser  User    `json:"createdUser"`
-	Updated      string  `json:"created"`
+	Created      Date    `json:"created"`
+	UpdatedUser  User    `json:"updatedUser"`
+	Updated      Date    `json:"updated"`
+}
+
+// CloneURL returns the URL used to clone the repository over SSH or HTTPS.
+func (r *Repository) CloneURL(ssh bool) string {
+	if ssh {
+		return r.SSHURL
+	}
+
+	return r.HTTPURL
+}
+
+// WebURL returns the URL of the repository page in the web UI. The form of HTTPURL is "https://spaceName.backlog.jp/git/PROJECT/repository.git".
+func (r *Repository) WebURL() string {
+	return strings.TrimSuffix(r.HTTPURL, ".git")
+}
+
+// BranchURL returns the URL of the branch page in the web UI.
+func (r *Repository) BranchURL(branch string) string {
+	return r.WebURL() + "/tree/" + escapeRef(branch)
+}
+
+// CommitURL returns the URL of the commit page in the web UI.
+func (r *Repository) CommitURL(hash string) string {
+	return r.WebURL() + "/commit/" + url.PathEscape(hash)
+}
+
+// escapeRef escapes each segment of the ref such as "feature/login".
+func escapeRef(ref string) string {
+	segments := strings.Split(ref, "/")
+
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return strings.Join(segments, "/")
 }
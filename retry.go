@@ -0,0 +1,107 @@
+package backlog
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryMiddleware returns the middleware which retries the request up to maxRetries times. The request is retried when Backlog responds with 429 Too Many Requests, and also on 5xx and network errors if the method is GET, since the other methods may have been applied. The wait starts at backoff and doubles on each retry, but the time in X-RateLimit-Reset is honoured for 429 if it is in the future. Each wait is capped at one minute, which is the window of the rate limit of Backlog, and it ends early when the context of the request is done.
+func RetryMiddleware(maxRetries int, backoff time.Duration) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			ctx := req.Context()
+			wait := backoff
+
+			for attempt := 0; ; attempt++ {
+				attemptReq, err := rewindRequest(req)
+				if err != nil {
+					return nil, err
+				}
+
+				res, err := next.Do(attemptReq)
+
+				if attempt >= maxRetries || !shouldRetry(req.Method, res, err) {
+					return res, err
+				}
+
+				delay := wait
+
+				if res != nil {
+					if res.StatusCode == http.StatusTooManyRequests {
+						if reset := rateLimitReset(res); reset.After(time.Now()) {
+							delay = time.Until(reset)
+						}
+					}
+					res.Body.Close()
+				}
+				if delay > maxRetryWait {
+					delay = maxRetryWait
+				}
+				if err = sleep(ctx, delay); err != nil {
+					return nil, err
+				}
+
+				wait *= 2
+			}
+		})
+	}
+}
+
+// maxRetryWait caps each wait, so that the broken X-RateLimit-Reset does not block the caller for hours.
+var maxRetryWait = time.Minute
+
+func shouldRetry(method string, res *http.Response, err error) bool {
+	if err != nil {
+		return method == http.MethodGet
+	}
+	if res.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	return res.StatusCode >= 500 && method == http.MethodGet
+}
+
+// rewindRequest returns the shallow copy of the request with the body reset to the beginning.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	if req.GetBody == nil || req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	rewound := req.WithContext(req.Context())
+	rewound.Body = body
+
+	return rewound, nil
+}
+
+// rateLimitReset returns the time in X-RateLimit-Reset, or the zero time if the header is missing.
+func rateLimitReset(res *http.Response) time.Time {
+	reset, err := strconv.ParseInt(res.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return time.Unix(reset, 0)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
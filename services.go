@@ -0,0 +1,240 @@
+package backlog
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"time"
+)
+
+//go:generate go run ./internal/genmock -o backlogmock/backlogmock.go
+
+// ProjectService is the set of the methods of Client for projects and their issue types.
+type ProjectService interface {
+	GetProjects(query url.Values) ([]*Project, error)
+	GetProjectsContext(ctx context.Context, query url.Values) ([]*Project, error)
+	GetIssueTypes(projectId int) ([]*IssueType, error)
+	GetIssueTypesContext(ctx context.Context, projectId int) ([]*IssueType, error)
+}
+
+// IssueService is the set of the methods of Client for issues.
+type IssueService interface {
+	GetIssues(query url.Values) ([]*Issue, error)
+	GetIssuesContext(ctx context.Context, query url.Values) ([]*Issue, error)
+	GetIssuesCount(query url.Values) (int, error)
+	GetIssuesCountContext(ctx context.Context, query url.Values) (int, error)
+	GetIssue(issueId string) (*Issue, error)
+	GetIssueContext(ctx context.Context, issueId string) (*Issue, error)
+	CreateIssue(values url.Values) (*Issue, error)
+	CreateIssueContext(ctx context.Context, values url.Values) (*Issue, error)
+	SetIssue(issueId string, values url.Values) (*Issue, error)
+	SetIssueContext(ctx context.Context, issueId string, values url.Values) (*Issue, error)
+	DeleteIssue(issueId int) (*Issue, error)
+	DeleteIssueContext(ctx context.Context, issueId int) (*Issue, error)
+	GetIssueHistory(issueId string) (*IssueHistory, error)
+	GetIssueHistoryContext(ctx context.Context, issueId string) (*IssueHistory, error)
+}
+
+// CommentService is the set of the methods of Client for comments on issues.
+type CommentService interface {
+	GetComments(issueId string, values url.Values) ([]*Comment, error)
+	GetCommentsContext(ctx context.Context, issueId string, values url.Values) ([]*Comment, error)
+	GetCommentsCount(issueId string) (int, error)
+	GetCommentsCountContext(ctx context.Context, issueId string) (int, error)
+	GetComment(issueId string, commentId int) (*Comment, error)
+	GetCommentContext(ctx context.Context, issueId string, commentId int) (*Comment, error)
+	AddComment(issueId, content string, notifiedUserIds, attachmentIds []int) (*Comment, error)
+	AddCommentContext(ctx context.Context, issueId, content string, notifiedUserIds, attachmentIds []int) (*Comment, error)
+	UpdateComment(issueId string, commentId int, content string) (*Comment, error)
+	UpdateCommentContext(ctx context.Context, issueId string, commentId int, content string) (*Comment, error)
+	DeleteComment(issueId string, commentId int) (*Comment, error)
+	DeleteCommentContext(ctx context.Context, issueId string, commentId int) (*Comment, error)
+	GetCommentNotifications(issueId string, commentId int) ([]*Notification, error)
+	GetCommentNotificationsContext(ctx context.Context, issueId string, commentId int) ([]*Notification, error)
+	AddCommentNotification(issueId string, commentId int, notifiedUserIds []int) (*Comment, error)
+	AddCommentNotificationContext(ctx context.Context, issueId string, commentId int, notifiedUserIds []int) (*Comment, error)
+}
+
+// UserService is the set of the methods of Client for users.
+type UserService interface {
+	GetMyself() (*User, error)
+	GetMyselfContext(ctx context.Context) (*User, error)
+	GetUsers() ([]*User, error)
+	GetUsersContext(ctx context.Context) ([]*User, error)
+}
+
+// SpaceService is the set of the methods of Client for space-wide resources.
+type SpaceService interface {
+	GetStatuses() ([]*Status, error)
+	GetStatusesContext(ctx context.Context) ([]*Status, error)
+	GetPriorities() ([]*Priority, error)
+	GetPrioritiesContext(ctx context.Context) ([]*Priority, error)
+	GetLicence() (*Licence, error)
+	GetLicenceContext(ctx context.Context) (*Licence, error)
+	GetRateLimit() (*RateLimit, error)
+	GetRateLimitContext(ctx context.Context) (*RateLimit, error)
+	GetCapabilities() (Capabilities, error)
+	GetCapabilitiesContext(ctx context.Context) (Capabilities, error)
+	UploadAttachment(name string, file io.Reader) (*Attachment, error)
+	UploadAttachmentContext(ctx context.Context, name string, file io.Reader) (*Attachment, error)
+}
+
+// RepositoryService is the set of the methods of Client for Git repositories.
+type RepositoryService interface {
+	GetRepositories(projectId string, query url.Values) ([]*Repository, error)
+	GetRepositoriesContext(ctx context.Context, projectId string, query url.Values) ([]*Repository, error)
+	GetRepository(projectId, repositoryId string) (*Repository, error)
+	GetRepositoryContext(ctx context.Context, projectId, repositoryId string) (*Repository, error)
+}
+
+// PullRequestService is the set of the methods of Client for pull requests, their comments and attachments.
+type PullRequestService interface {
+	GetPullRequests(projectID, repositoryID string, query url.Values) ([]*PullRequest, error)
+	GetPullRequestsContext(ctx context.Context, projectID, repositoryID string, query url.Values) ([]*PullRequest, error)
+	GetPullRequestsCount(projectID, repositoryID string, query url.Values) (int, error)
+	GetPullRequestsCountContext(ctx context.Context, projectID, repositoryID string, query url.Values) (int, error)
+	GetPullRequest(projectID, repositoryID string, number int, query url.Values) (*PullRequest, error)
+	GetPullRequestContext(ctx context.Context, projectID, repositoryID string, number int, query url.Values) (*PullRequest, error)
+	CreatePullRequest(projectId, repositoryId string, values url.Values) (*PullRequest, error)
+	CreatePullRequestContext(ctx context.Context, projectId, repositoryId string, values url.Values) (*PullRequest, error)
+	UpdatePullRequest(projectId, repositoryId string, number int, values url.Values) (*PullRequest, error)
+	UpdatePullRequestContext(ctx context.Context, projectId, repositoryId string, number int, values url.Values) (*PullRequest, error)
+	GetPullRequestComments(projectId, repositoryId string, number int, query url.Values) ([]*PullRequestComment, error)
+	GetPullRequestCommentsContext(ctx context.Context, projectId, repositoryId string, number int, query url.Values) ([]*PullRequestComment, error)
+	GetPullRequestCommentsCount(projectId, repositoryId string, number int) (int, error)
+	GetPullRequestCommentsCountContext(ctx context.Context, projectId, repositoryId string, number int) (int, error)
+	AddPullRequestComment(projectId, repositoryId string, number int, content string, notifiedUserIds []int) (*PullRequestComment, error)
+	AddPullRequestCommentContext(ctx context.Context, projectId, repositoryId string, number int, content string, notifiedUserIds []int) (*PullRequestComment, error)
+	UpdatePullRequestComment(projectId, repositoryId string, number, commentId int, content string) (*PullRequestComment, error)
+	UpdatePullRequestCommentContext(ctx context.Context, projectId, repositoryId string, number, commentId int, content string) (*PullRequestComment, error)
+	GetPullRequestAttachments(projectId, repositoryId string, number int) ([]*Attachment, error)
+	GetPullRequestAttachmentsContext(ctx context.Context, projectId, repositoryId string, number int) ([]*Attachment, error)
+	DownloadPullRequestAttachment(projectId, repositoryId string, number, attachmentId int) ([]byte, error)
+	DownloadPullRequestAttachmentContext(ctx context.Context, projectId, repositoryId string, number, attachmentId int) ([]byte, error)
+	DeletePullRequestAttachment(projectId, repositoryId string, number, attachmentId int) (*Attachment, error)
+	DeletePullRequestAttachmentContext(ctx context.Context, projectId, repositoryId string, number, attachmentId int) (*Attachment, error)
+}
+
+// WikiService is the set of the methods of Client for wiki pages, their attachments, shared files, history and stars.
+type WikiService interface {
+	GetWikis(projectId string, query url.Values) ([]*Wiki, error)
+	GetWikisContext(ctx context.Context, projectId string, query url.Values) ([]*Wiki, error)
+	GetWikisCount(projectId string) (int, error)
+	GetWikisCountContext(ctx context.Context, projectId string) (int, error)
+	GetWikiTags(projectId string) ([]*WikiTag, error)
+	GetWikiTagsContext(ctx context.Context, projectId string) ([]*WikiTag, error)
+	GetWiki(wikiId int) (*Wiki, error)
+	GetWikiContext(ctx context.Context, wikiId int) (*Wiki, error)
+	AddWiki(projectId int, name, content string, mailNotify bool) (*Wiki, error)
+	AddWikiContext(ctx context.Context, projectId int, name, content string, mailNotify bool) (*Wiki, error)
+	UpdateWiki(wikiId int, name, content string, mailNotify bool) (*Wiki, error)
+	UpdateWikiContext(ctx context.Context, wikiId int, name, content string, mailNotify bool) (*Wiki, error)
+	DeleteWiki(wikiId int, mailNotify bool) (*Wiki, error)
+	DeleteWikiContext(ctx context.Context, wikiId int, mailNotify bool) (*Wiki, error)
+	GetWikiAttachments(wikiId int) ([]*Attachment, error)
+	GetWikiAttachmentsContext(ctx context.Context, wikiId int) ([]*Attachment, error)
+	AddWikiAttachments(wikiId int, attachmentIds []int) ([]*Attachment, error)
+	AddWikiAttachmentsContext(ctx context.Context, wikiId int, attachmentIds []int) ([]*Attachment, error)
+	DownloadWikiAttachment(wikiId, attachmentId int) ([]byte, error)
+	DownloadWikiAttachmentContext(ctx context.Context, wikiId, attachmentId int) ([]byte, error)
+	DeleteWikiAttachment(wikiId, attachmentId int) (*Attachment, error)
+	DeleteWikiAttachmentContext(ctx context.Context, wikiId, attachmentId int) (*Attachment, error)
+	GetWikiSharedFiles(wikiId int) ([]*SharedFile, error)
+	GetWikiSharedFilesContext(ctx context.Context, wikiId int) ([]*SharedFile, error)
+	LinkWikiSharedFiles(wikiId int, fileIds []int) ([]*SharedFile, error)
+	LinkWikiSharedFilesContext(ctx context.Context, wikiId int, fileIds []int) ([]*SharedFile, error)
+	UnlinkWikiSharedFile(wikiId, sharedFileId int) (*SharedFile, error)
+	UnlinkWikiSharedFileContext(ctx context.Context, wikiId, sharedFileId int) (*SharedFile, error)
+	GetWikiHistory(wikiId int, query url.Values) ([]*WikiHistory, error)
+	GetWikiHistoryContext(ctx context.Context, wikiId int, query url.Values) ([]*WikiHistory, error)
+	GetWikiStars(wikiId int) ([]*Star, error)
+	GetWikiStarsContext(ctx context.Context, wikiId int) ([]*Star, error)
+}
+
+// StarService is the set of the methods of Client for stars.
+type StarService interface {
+	AddStar(target StarTarget, id int) error
+	AddStarContext(ctx context.Context, target StarTarget, id int) error
+	RemoveStar(starId int) error
+	RemoveStarContext(ctx context.Context, starId int) error
+	GetUserStars(userId int, query url.Values) ([]*Star, error)
+	GetUserStarsContext(ctx context.Context, userId int, query url.Values) ([]*Star, error)
+	GetUserStarsCount(userId int, since, until time.Time) (int, error)
+	GetUserStarsCountContext(ctx context.Context, userId int, since, until time.Time) (int, error)
+}
+
+// NotificationService is the set of the methods of Client for notifications.
+type NotificationService interface {
+	GetNotifications(query url.Values) ([]*Notification, error)
+	GetNotificationsContext(ctx context.Context, query url.Values) ([]*Notification, error)
+	GetNotificationsCount(query url.Values) (int, error)
+	GetNotificationsCountContext(ctx context.Context, query url.Values) (int, error)
+	ResetNotificationCount() (int, error)
+	ResetNotificationCountContext(ctx context.Context) (int, error)
+	MarkNotificationRead(notificationId int) error
+	MarkNotificationReadContext(ctx context.Context, notificationId int) error
+}
+
+// WatchingService is the set of the methods of Client for watchings.
+type WatchingService interface {
+	GetWatchings(userId int, query url.Values) ([]*Watching, error)
+	GetWatchingsContext(ctx context.Context, userId int, query url.Values) ([]*Watching, error)
+	GetWatchingsCount(userId int, query url.Values) (int, error)
+	GetWatchingsCountContext(ctx context.Context, userId int, query url.Values) (int, error)
+	GetWatching(watchingId int) (*Watching, error)
+	GetWatchingContext(ctx context.Context, watchingId int) (*Watching, error)
+	AddWatching(issueId, note string) (*Watching, error)
+	AddWatchingContext(ctx context.Context, issueId, note string) (*Watching, error)
+	UpdateWatching(watchingId int, note string) (*Watching, error)
+	UpdateWatchingContext(ctx context.Context, watchingId int, note string) (*Watching, error)
+	DeleteWatching(watchingId int) (*Watching, error)
+	DeleteWatchingContext(ctx context.Context, watchingId int) (*Watching, error)
+	MarkWatchingRead(watchingId int) error
+	MarkWatchingReadContext(ctx context.Context, watchingId int) error
+}
+
+// WebhookService is the set of the methods of Client for webhooks.
+type WebhookService interface {
+	GetWebhooks(projectId string) ([]*Webhook, error)
+	GetWebhooksContext(ctx context.Context, projectId string) ([]*Webhook, error)
+	GetWebhook(projectId string, webhookId int) (*Webhook, error)
+	GetWebhookContext(ctx context.Context, projectId string, webhookId int) (*Webhook, error)
+	AddWebhook(projectId string, webhook *Webhook) (*Webhook, error)
+	AddWebhookContext(ctx context.Context, projectId string, webhook *Webhook) (*Webhook, error)
+	UpdateWebhook(projectId string, webhook *Webhook) (*Webhook, error)
+	UpdateWebhookContext(ctx context.Context, projectId string, webhook *Webhook) (*Webhook, error)
+	DeleteWebhook(projectId string, webhookId int) (*Webhook, error)
+	DeleteWebhookContext(ctx context.Context, projectId string, webhookId int) (*Webhook, error)
+}
+
+// API is the set of all methods of Client which call the API. Accept the narrowest service in the code instead where possible.
+type API interface {
+	ProjectService
+	IssueService
+	CommentService
+	UserService
+	SpaceService
+	RepositoryService
+	PullRequestService
+	WikiService
+	StarService
+	NotificationService
+	WatchingService
+	WebhookService
+}
+
+var (
+	_ ProjectService      = (*Client)(nil)
+	_ IssueService        = (*Client)(nil)
+	_ CommentService      = (*Client)(nil)
+	_ UserService         = (*Client)(nil)
+	_ SpaceService        = (*Client)(nil)
+	_ RepositoryService   = (*Client)(nil)
+	_ PullRequestService  = (*Client)(nil)
+	_ WikiService         = (*Client)(nil)
+	_ StarService         = (*Client)(nil)
+	_ NotificationService = (*Client)(nil)
+	_ WatchingService     = (*Client)(nil)
+	_ WebhookService      = (*Client)(nil)
+	_ API                 = (*Client)(nil)
+)
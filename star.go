@@ -8,3 +8,14 @@ type Star struct {
 	Presenter User   `json:"presenter"`
 	Created   Date   `json:"created"`
 }
+
+// StarTarget represents the kind of the resource to which a star is added.
+type StarTarget string
+
+const (
+	StarTargetIssue              StarTarget = "issueId"
+	StarTargetComment            StarTarget = "commentId"
+	StarTargetWiki               StarTarget = "wikiId"
+	StarTargetPullRequest        StarTarget = "pullRequestId"
+	StarTargetPullRequestComment StarTarget = "pullRequestCommentId"
+)
@@ -0,0 +1,9 @@
+package backlog
+
+// TextFormattingRule represents the notation used for the content of issues, comments and wikis in the project.
+type TextFormattingRule string
+
+const (
+	TextFormattingRuleBacklog  TextFormattingRule = "backlog"
+	TextFormattingRuleMarkdown TextFormattingRule = "markdown"
+)
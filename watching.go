@@ -0,0 +1,12 @@
+package backlog
+
+type Watching struct {
+	Id                  int    `json:"id"`
+	ResourceAlreadyRead bool   `json:"resourceAlreadyRead"`
+	Note                string `json:"note"`
+	Type                string `json:"type"`
+	Issue               Issue  `json:"issue"`
+	LastContentUpdated  Date   `json:"lastContentUpdated"`
+	Created             Date   `json:"created"`
+	Updated             Date   `json:"updated"`
+}
@@ -0,0 +1,58 @@
+package backlog
+
+import (
+	"net/url"
+	"strconv"
+)
+
+type Webhook struct {
+	Id              int            `json:"id"`
+	Name            string         `json:"name"`
+	Description     string         `json:"description"`
+	HookURL         string         `json:"hookUrl"`
+	AllEvent        bool           `json:"allEvent"`
+	ActivityTypeIds []ActivityType `json:"activityTypeIds"`
+	CreatedUser     User           `json:"createdUser"`
+	Created         Date           `json:"created"`
+	UpdatedUser     User           `json:"updatedUser"`
+	Updated         Date           `json:"updated"`
+}
+
+// values returns the form values used to add the webhook.
+func (w *Webhook) values() url.Values {
+	values := url.Values{}
+	values.Set("name", w.Name)
+	values.Set("description", w.Description)
+	values.Set("hookUrl", w.HookURL)
+	values.Set("allEvent", strconv.FormatBool(w.AllEvent))
+
+	for _, activityType := range w.ActivityTypeIds {
+		values.Add("activityTypeIds[]", strconv.Itoa(int(activityType)))
+	}
+
+	return values
+}
+
+// updateValues returns the form values used to update the webhook. The empty fields are left unchanged. AllEvent is sent if it is true, or as false if ActivityTypeIds is given.
+func (w *Webhook) updateValues() url.Values {
+	values := url.Values{}
+
+	if w.Name != "" {
+		values.Set("name", w.Name)
+	}
+	if w.Description != "" {
+		values.Set("description", w.Description)
+	}
+	if w.HookURL != "" {
+		values.Set("hookUrl", w.HookURL)
+	}
+	if w.AllEvent || len(w.ActivityTypeIds) > 0 {
+		values.Set("allEvent", strconv.FormatBool(w.AllEvent))
+	}
+
+	for _, activityType := range w.ActivityTypeIds {
+		values.Add("activityTypeIds[]", strconv.Itoa(int(activityType)))
+	}
+
+	return values
+}
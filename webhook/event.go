@@ -0,0 +1,108 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+
+	backlog "github.com/moutend/go-backlog"
+)
+
+// Event represents the payload posted by Backlog. Content holds the raw JSON whose shape depends on Type.
+type Event struct {
+	Id            int                    `json:"id"`
+	Type          backlog.ActivityType   `json:"type"`
+	Project       backlog.Project        `json:"project"`
+	Content       json.RawMessage        `json:"content"`
+	Notifications []backlog.Notification `json:"notifications"`
+	CreatedUser   backlog.User           `json:"createdUser"`
+	Created       backlog.Date           `json:"created"`
+}
+
+// Change represents a field changed by the event. Unlike backlog.ChangeLog, the keys in the payload are snake case.
+type Change struct {
+	Field    string `json:"field"`
+	NewValue string `json:"new_value"`
+	OldValue string `json:"old_value"`
+	Type     string `json:"type"`
+}
+
+// IssueEvent is dispatched when the issue is created, updated, commented or deleted.
+type IssueEvent struct {
+	*Event
+	Issue   backlog.Issue
+	Comment *backlog.Comment
+	Changes []Change
+}
+
+// PullRequestEvent is dispatched when the pull request is added, updated, commented or deleted.
+type PullRequestEvent struct {
+	*Event
+	PullRequest backlog.PullRequest
+	Comment     *backlog.Comment
+	Changes     []Change
+}
+
+type content struct {
+	KeyId   int              `json:"key_id"`
+	Comment *backlog.Comment `json:"comment"`
+	Changes []Change         `json:"changes"`
+}
+
+func isIssueEvent(t backlog.ActivityType) bool {
+	switch t {
+	case backlog.ActivityTypeIssueCreated, backlog.ActivityTypeIssueUpdated, backlog.ActivityTypeIssueCommented, backlog.ActivityTypeIssueDeleted:
+		return true
+	}
+
+	return false
+}
+
+func isPullRequestEvent(t backlog.ActivityType) bool {
+	switch t {
+	case backlog.ActivityTypePullRequestAdded, backlog.ActivityTypePullRequestUpdated, backlog.ActivityTypePullRequestCommented, backlog.ActivityTypePullRequestDeleted:
+		return true
+	}
+
+	return false
+}
+
+func newIssueEvent(event *Event) (*IssueEvent, error) {
+	var c content
+
+	issueEvent := &IssueEvent{Event: event}
+
+	if err := json.Unmarshal(event.Content, &issueEvent.Issue); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(event.Content, &c); err != nil {
+		return nil, err
+	}
+
+	// The payload has key_id instead of keyId and issueKey.
+	issueEvent.Issue.KeyId = c.KeyId
+	issueEvent.Issue.IssueKey = fmt.Sprintf("%s-%d", event.Project.ProjectKey, c.KeyId)
+	issueEvent.Issue.ProjectId = event.Project.Id
+	issueEvent.Comment = c.Comment
+	issueEvent.Changes = c.Changes
+
+	return issueEvent, nil
+}
+
+func newPullRequestEvent(event *Event) (*PullRequestEvent, error) {
+	var c content
+
+	pullRequestEvent := &PullRequestEvent{Event: event}
+
+	if err := json.Unmarshal(event.Content, &pullRequestEvent.PullRequest); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(event.Content, &c); err != nil {
+		return nil, err
+	}
+
+	pullRequestEvent.PullRequest.ProjectId = event.Project.Id
+	pullRequestEvent.Comment = c.Comment
+	pullRequestEvent.Changes = c.Changes
+
+	return pullRequestEvent, nil
+}
@@ -0,0 +1,158 @@
+// Package webhook provides the http.Handler which receives the webhooks posted by Backlog.
+//
+// The handler replies to Backlog as soon as the payload is parsed, and the registered callbacks are called in the background. Call Wait before shutting down to make sure all the callbacks have returned.
+package webhook
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sync"
+
+	backlog "github.com/moutend/go-backlog"
+)
+
+// MaxBodySize is the maximum size of the payload accepted by the handler.
+const MaxBodySize = 1 << 20
+
+type Handler struct {
+	// Secret is compared with the value of the "secret" query parameter of the webhook URL. The empty Secret disables the verification.
+	Secret string
+	// ErrorLog is used to report the payloads which could not be decoded and the callbacks which panicked. The standard logger is used if it is nil.
+	ErrorLog *log.Logger
+
+	mu           sync.RWMutex
+	wg           sync.WaitGroup
+	handlers     map[backlog.ActivityType][]func(*Event)
+	issues       []func(*IssueEvent)
+	pullRequests []func(*PullRequestEvent)
+}
+
+func NewHandler(secret string) *Handler {
+	return &Handler{
+		Secret:   secret,
+		handlers: map[backlog.ActivityType][]func(*Event){},
+	}
+}
+
+// On registers the callback called for the events of the activity type.
+func (h *Handler) On(activityType backlog.ActivityType, fn func(*Event)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// The map is created here so that the zero value of Handler is usable.
+	if h.handlers == nil {
+		h.handlers = map[backlog.ActivityType][]func(*Event){}
+	}
+
+	h.handlers[activityType] = append(h.handlers[activityType], fn)
+}
+
+// OnIssue registers the callback called when the issue is created, updated, commented or deleted.
+func (h *Handler) OnIssue(fn func(*IssueEvent)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.issues = append(h.issues, fn)
+}
+
+// OnPullRequest registers the callback called when the pull request is added, updated, commented or deleted.
+func (h *Handler) OnPullRequest(fn func(*PullRequestEvent)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.pullRequests = append(h.pullRequests, fn)
+}
+
+// Wait blocks until all the callbacks in progress have returned.
+func (h *Handler) Wait() {
+	h.wg.Wait()
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if h.Secret != "" && subtle.ConstantTimeCompare([]byte(r.URL.Query().Get("secret")), []byte(h.Secret)) != 1 {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodySize))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	var event Event
+
+	if err = json.Unmarshal(body, &event); err != nil {
+		h.logf("webhook: failed to decode payload: %v", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		h.dispatch(&event)
+	}()
+}
+
+func (h *Handler) dispatch(event *Event) {
+	h.mu.RLock()
+	handlers := h.handlers[event.Type]
+	issues := h.issues
+	pullRequests := h.pullRequests
+	h.mu.RUnlock()
+
+	for _, fn := range handlers {
+		h.call(func() { fn(event) })
+	}
+	if isIssueEvent(event.Type) && len(issues) > 0 {
+		issueEvent, err := newIssueEvent(event)
+		if err != nil {
+			h.logf("webhook: failed to decode issue event %d: %v", event.Id, err)
+			return
+		}
+		for _, fn := range issues {
+			h.call(func() { fn(issueEvent) })
+		}
+	}
+	if isPullRequestEvent(event.Type) && len(pullRequests) > 0 {
+		pullRequestEvent, err := newPullRequestEvent(event)
+		if err != nil {
+			h.logf("webhook: failed to decode pull request event %d: %v", event.Id, err)
+			return
+		}
+		for _, fn := range pullRequests {
+			h.call(func() { fn(pullRequestEvent) })
+		}
+	}
+}
+
+// call recovers the panic in the callback so that the other callbacks are still called.
+func (h *Handler) call(fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			h.logf("webhook: callback panicked: %v", r)
+		}
+	}()
+
+	fn()
+}
+
+func (h *Handler) logf(format string, v ...interface{}) {
+	if h.ErrorLog != nil {
+		h.ErrorLog.Printf(format, v...)
+		return
+	}
+
+	log.Printf(format, v...)
+}
@@ -0,0 +1,139 @@
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	backlog "github.com/moutend/go-backlog"
+)
+
+const issueCommented = `{
+  "created": "2017-08-08T01:12:21Z",
+  "project": {
+    "id": 51884,
+    "projectKey": "SAMPLE",
+    "name": "sample",
+    "textFormattingRule": "markdown"
+  },
+  "id": 7,
+  "type": 3,
+  "content": {
+    "id": 6763069,
+    "key_id": 36,
+    "summary": "summery of the issue",
+    "description": "description of the issue",
+    "status": {
+      "id": 2,
+      "name": "ongoing"
+    },
+    "customFields": [
+      {
+        "id": 55,
+        "fieldTypeId": 6,
+        "name": "severity",
+        "value": {
+          "id": 1,
+          "name": "high"
+        }
+      }
+    ],
+    "comment": {
+      "id": 67890,
+      "content": "deployed to staging"
+    },
+    "changes": [
+      {
+        "field": "status",
+        "new_value": "3",
+        "old_value": "2",
+        "type": "standard"
+      }
+    ]
+  },
+  "notifications": [],
+  "createdUser": {
+    "id": 137435,
+    "name": "foo"
+  }
+}`
+
+func TestHandler(t *testing.T) {
+	var got *IssueEvent
+	var raw *Event
+
+	h := NewHandler("s3cret")
+	h.OnIssue(func(event *IssueEvent) {
+		got = event
+	})
+	h.On(backlog.ActivityTypeIssueCommented, func(event *Event) {
+		raw = event
+	})
+
+	r := httptest.NewRequest("POST", "/hook?secret=s3cret", strings.NewReader(issueCommented))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	h.Wait()
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if raw == nil || raw.Id != 7 {
+		t.Fatalf("unexpected event %+v", raw)
+	}
+	if got == nil {
+		t.Fatal("issue callback was not called")
+	}
+	if got.Issue.IssueKey != "SAMPLE-36" {
+		t.Fatalf("expected SAMPLE-36, got %v", got.Issue.IssueKey)
+	}
+	if got.Comment == nil || got.Comment.Content != "deployed to staging" {
+		t.Fatalf("unexpected comment %+v", got.Comment)
+	}
+	if len(got.Changes) != 1 || got.Changes[0].NewValue != "3" {
+		t.Fatalf("unexpected changes %+v", got.Changes)
+	}
+}
+
+func TestZeroHandler(t *testing.T) {
+	var raw *Event
+
+	h := &Handler{}
+	h.On(backlog.ActivityTypeIssueCommented, func(event *Event) {
+		raw = event
+	})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/hook", strings.NewReader(issueCommented)))
+	h.Wait()
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if raw == nil || raw.Id != 7 {
+		t.Fatalf("unexpected event %+v", raw)
+	}
+}
+
+func TestHandlerRejectsRequest(t *testing.T) {
+	h := NewHandler("s3cret")
+
+	for _, tc := range []struct {
+		method string
+		target string
+		body   string
+		code   int
+	}{
+		{"GET", "/hook?secret=s3cret", "", http.StatusMethodNotAllowed},
+		{"POST", "/hook?secret=wrong", issueCommented, http.StatusForbidden},
+		{"POST", "/hook?secret=s3cret", "{", http.StatusBadRequest},
+	} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body)))
+
+		if w.Code != tc.code {
+			t.Errorf("%s %s: expected %d, got %d", tc.method, tc.target, tc.code, w.Code)
+		}
+	}
+}
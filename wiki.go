@@ -0,0 +1,16 @@
+package backlog
+
+type Wiki struct {
+	Id          int          `json:"id"`
+	ProjectId   int          `json:"projectId"`
+	Name        string       `json:"name"`
+	Content     string       `json:"content"`
+	Tags        []WikiTag    `json:"tags"`
+	Attachments []Attachment `json:"attachments"`
+	SharedFiles []SharedFile `json:"sharedFiles"`
+	Stars       []Star       `json:"stars"`
+	CreatedUser User         `json:"createdUser"`
+	Created     Date         `json:"created"`
+	UpdatedUser User         `json:"updatedUser"`
+	Updated     Date         `json:"updated"`
+}
@@ -0,0 +1,10 @@
+package backlog
+
+type WikiHistory struct {
+	PageId      int    `json:"pageId"`
+	Version     int    `json:"version"`
+	Name        string `json:"name"`
+	Content     string `json:"content"`
+	CreatedUser User   `json:"createdUser"`
+	Created     Date   `json:"created"`
+}
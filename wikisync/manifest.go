@@ -0,0 +1,89 @@
+package wikisync
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// ManifestName is the name of the file which records the state of the last synchronization. It is placed at the root of the mirror directory.
+const ManifestName = ".wikisync.json"
+
+type entry struct {
+	Id      int    `json:"id"`
+	Name    string `json:"name"`
+	Hash    string `json:"hash"`
+	Updated string `json:"updated"`
+}
+
+type manifest struct {
+	Pages []entry `json:"pages"`
+}
+
+func loadManifest(dir string) (*manifest, error) {
+	var m manifest
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, ManifestName))
+	if os.IsNotExist(err) {
+		return &m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (m *manifest) save(dir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(dir, ManifestName), append(data, '\n'), 0644)
+}
+
+func (m *manifest) byId(id int) *entry {
+	for i := range m.Pages {
+		if m.Pages[i].Id == id {
+			return &m.Pages[i]
+		}
+	}
+
+	return nil
+}
+
+func (m *manifest) byName(name string) *entry {
+	for i := range m.Pages {
+		if m.Pages[i].Name == name {
+			return &m.Pages[i]
+		}
+	}
+
+	return nil
+}
+
+func (m *manifest) put(e entry) {
+	if old := m.byId(e.Id); old != nil {
+		*old = e
+		return
+	}
+
+	m.Pages = append(m.Pages, e)
+}
+
+func (m *manifest) remove(id int) {
+	for i := range m.Pages {
+		if m.Pages[i].Id == id {
+			m.Pages = append(m.Pages[:i], m.Pages[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,489 @@
+// Package wikisync mirrors the wiki pages of a Backlog project to a local directory.
+//
+// Each page is written to a file named after the page. The slash in the page name becomes the directory separator, so "Runbooks/Deploy" is mirrored to "Runbooks/Deploy.md" when the project uses Markdown. The state of the last synchronization is recorded in the manifest file at the root of the directory, and it is used to detect which side has been edited since then.
+package wikisync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	backlog "github.com/moutend/go-backlog"
+)
+
+// API is the subset of *backlog.Client used by Syncer.
+type API interface {
+	GetWikisContext(ctx context.Context, projectId string, query url.Values) ([]*backlog.Wiki, error)
+	GetWikiContext(ctx context.Context, wikiId int) (*backlog.Wiki, error)
+	AddWikiContext(ctx context.Context, projectId int, name, content string, mailNotify bool) (*backlog.Wiki, error)
+	UpdateWikiContext(ctx context.Context, wikiId int, name, content string, mailNotify bool) (*backlog.Wiki, error)
+	DeleteWikiContext(ctx context.Context, wikiId int, mailNotify bool) (*backlog.Wiki, error)
+}
+
+// Action represents what has to be done to bring both sides in sync.
+type Action int
+
+const (
+	// Pull overwrites the local file with the remote page.
+	Pull Action = iota
+	// Push creates or updates the remote page with the local file.
+	Push
+	// DeleteLocal removes the local file because the remote page has been deleted.
+	DeleteLocal
+	// DeleteRemote deletes the remote page because the local file has been removed.
+	DeleteRemote
+	// Conflict means that both sides have been edited since the last synchronization. The untracked local file whose content differs from the page of the same name, and the page renamed onto the existing local file, are also reported as the conflict.
+	Conflict
+)
+
+func (a Action) String() string {
+	switch a {
+	case Pull:
+		return "pull"
+	case Push:
+		return "push"
+	case DeleteLocal:
+		return "delete local"
+	case DeleteRemote:
+		return "delete remote"
+	case Conflict:
+		return "conflict"
+	default:
+		return "unknown"
+	}
+}
+
+// Change represents a page which differs between the local directory and the project.
+type Change struct {
+	Action Action
+	Name   string
+	WikiId int
+}
+
+// Resolution decides how the conflicts are resolved.
+type Resolution int
+
+const (
+	// Manual reports the conflicts with ConflictError and leaves both sides as they are.
+	Manual Resolution = iota
+	// KeepLocal resolves the conflicts with Push, or DeleteRemote when the local file has been removed, so the local edits win.
+	KeepLocal
+	// KeepRemote resolves the conflicts with Pull, or DeleteLocal when the page has been deleted, so the remote edits win.
+	KeepRemote
+)
+
+// ConflictError is returned when some pages have been edited on both sides. The other changes are applied regardless. Set Syncer.Resolve to resolve them.
+type ConflictError struct {
+	Names []string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("wikisync: conflict in %s", strings.Join(e.Names, ", "))
+}
+
+type Syncer struct {
+	client  API
+	project *backlog.Project
+	dir     string
+
+	// MailNotify is passed to the API when pages are created or updated.
+	MailNotify bool
+	// Prune allows Push to delete the remote pages whose local files have been removed.
+	Prune bool
+	// Resolve decides how the conflicts are resolved. Plan reports the resolved actions instead of Conflict unless it is Manual.
+	Resolve Resolution
+}
+
+func New(client API, project *backlog.Project, dir string) *Syncer {
+	return &Syncer{
+		client:  client,
+		project: project,
+		dir:     dir,
+	}
+}
+
+// Ext returns the file extension used for the pages, which depends on the TextFormattingRule of the project.
+func (s *Syncer) Ext() string {
+	if s.project.TextFormattingRule == backlog.TextFormattingRuleMarkdown {
+		return ".md"
+	}
+
+	return ".backlog"
+}
+
+// Plan compares the local directory with the project and returns the changes without applying them.
+func (s *Syncer) Plan(ctx context.Context) ([]Change, error) {
+	changes, _, err := s.plan(ctx)
+
+	return changes, err
+}
+
+// Pull applies the remote edits to the local directory.
+func (s *Syncer) Pull(ctx context.Context) ([]Change, error) {
+	return s.apply(ctx, Pull, DeleteLocal)
+}
+
+// Push applies the local edits to the project.
+func (s *Syncer) Push(ctx context.Context) ([]Change, error) {
+	return s.apply(ctx, Push, DeleteRemote)
+}
+
+// Sync applies the edits in both directions.
+func (s *Syncer) Sync(ctx context.Context) ([]Change, error) {
+	return s.apply(ctx, Pull, DeleteLocal, Push, DeleteRemote)
+}
+
+func (s *Syncer) plan(ctx context.Context) ([]Change, *manifest, error) {
+	m, err := loadManifest(s.dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	remote, err := s.client.GetWikisContext(ctx, s.project.ProjectKey, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	local, err := s.scan()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var changes []Change
+
+	seen := map[string]bool{}
+	exists := map[int]bool{}
+
+	for _, wiki := range remote {
+		exists[wiki.Id] = true
+		e := m.byId(wiki.Id)
+
+		if e == nil {
+			seen[wiki.Name] = true
+			content, ok := local[wiki.Name]
+
+			if !ok {
+				changes = append(changes, Change{Action: Pull, Name: wiki.Name, WikiId: wiki.Id})
+				continue
+			}
+
+			// The page is not recorded in the manifest, such as on the first synchronization into the existing directory, so the content is compared.
+			page, err := s.client.GetWikiContext(ctx, wiki.Id)
+			if err != nil {
+				return nil, nil, err
+			}
+			if hash(page.Content) == hash(content) {
+				m.put(entry{Id: wiki.Id, Name: wiki.Name, Hash: hash(content), Updated: string(wiki.Updated)})
+			} else {
+				changes = append(changes, s.resolve(
+					Change{Action: Push, Name: wiki.Name, WikiId: wiki.Id},
+					Change{Action: Pull, Name: wiki.Name, WikiId: wiki.Id}))
+			}
+			continue
+		}
+
+		seen[e.Name] = true
+		renamed := wiki.Name != e.Name
+		remoteChanged := string(wiki.Updated) != e.Updated || renamed
+		content, ok := local[e.Name]
+
+		if renamed {
+			seen[wiki.Name] = true
+
+			// Pulling the page renamed onto the existing local file would overwrite it, and the file would be pushed as another page.
+			if _, exists := local[wiki.Name]; exists {
+				changes = append(changes, s.resolve(
+					Change{Action: Push, Name: wiki.Name, WikiId: wiki.Id},
+					Change{Action: Pull, Name: wiki.Name, WikiId: wiki.Id}))
+				continue
+			}
+		}
+
+		localChanged := ok && hash(content) != e.Hash
+
+		// Both sides may have been edited in the same way, such as when the edit was pushed by another tool, so the content is compared before it is reported as the conflict.
+		if localChanged && remoteChanged && !renamed {
+			page, err := s.client.GetWikiContext(ctx, wiki.Id)
+			if err != nil {
+				return nil, nil, err
+			}
+			if hash(page.Content) == hash(content) {
+				m.put(entry{Id: wiki.Id, Name: wiki.Name, Hash: hash(content), Updated: string(wiki.Updated)})
+				continue
+			}
+		}
+
+		switch {
+		case !ok && remoteChanged:
+			// The removed file is restored rather than deleting the edited page.
+			changes = append(changes, Change{Action: Pull, Name: wiki.Name, WikiId: wiki.Id})
+		case !ok:
+			changes = append(changes, Change{Action: DeleteRemote, Name: e.Name, WikiId: wiki.Id})
+		case localChanged && remoteChanged:
+			changes = append(changes, s.resolve(
+				Change{Action: Push, Name: e.Name, WikiId: wiki.Id},
+				Change{Action: Pull, Name: wiki.Name, WikiId: wiki.Id}))
+		case localChanged:
+			changes = append(changes, Change{Action: Push, Name: e.Name, WikiId: wiki.Id})
+		case remoteChanged:
+			changes = append(changes, Change{Action: Pull, Name: wiki.Name, WikiId: wiki.Id})
+		}
+	}
+	for _, e := range m.Pages {
+		if exists[e.Id] {
+			continue
+		}
+
+		seen[e.Name] = true
+		content, ok := local[e.Name]
+
+		if ok && hash(content) != e.Hash {
+			// Keeping the local file creates the page again.
+			changes = append(changes, s.resolve(
+				Change{Action: Push, Name: e.Name},
+				Change{Action: DeleteLocal, Name: e.Name, WikiId: e.Id}))
+		} else {
+			changes = append(changes, Change{Action: DeleteLocal, Name: e.Name, WikiId: e.Id})
+		}
+	}
+	for name := range local {
+		if !seen[name] {
+			changes = append(changes, Change{Action: Push, Name: name})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+
+	return changes, m, nil
+}
+
+// resolve returns the change which keeps the side chosen by Resolve, or the conflict.
+func (s *Syncer) resolve(keepLocal, keepRemote Change) Change {
+	switch s.Resolve {
+	case KeepLocal:
+		return keepLocal
+	case KeepRemote:
+		return keepRemote
+	default:
+		return Change{Action: Conflict, Name: keepRemote.Name, WikiId: keepRemote.WikiId}
+	}
+}
+
+func (s *Syncer) apply(ctx context.Context, actions ...Action) ([]Change, error) {
+	changes, m, err := s.plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := map[Action]bool{}
+
+	for _, action := range actions {
+		allowed[action] = true
+	}
+
+	var applied []Change
+	var conflicts []string
+
+	for _, change := range changes {
+		if change.Action == Conflict {
+			conflicts = append(conflicts, change.Name)
+			continue
+		}
+		if !allowed[change.Action] || (change.Action == DeleteRemote && !s.Prune) {
+			continue
+		}
+		if err = s.applyChange(ctx, m, change); err != nil {
+			break
+		}
+
+		applied = append(applied, change)
+	}
+
+	// The manifest is saved even if an error occurred, so that the applied changes are not detected again.
+	if saveErr := m.save(s.dir); err == nil {
+		err = saveErr
+	}
+	if err == nil && len(conflicts) > 0 {
+		err = &ConflictError{Names: conflicts}
+	}
+
+	return applied, err
+}
+
+func (s *Syncer) applyChange(ctx context.Context, m *manifest, change Change) error {
+	switch change.Action {
+	case Pull:
+		wiki, err := s.client.GetWikiContext(ctx, change.WikiId)
+		if err != nil {
+			return err
+		}
+		if err = s.write(wiki.Name, wiki.Content); err != nil {
+			return err
+		}
+		if e := m.byId(wiki.Id); e != nil && e.Name != wiki.Name {
+			if err = s.remove(e.Name); err != nil {
+				return err
+			}
+		}
+
+		m.put(entry{Id: wiki.Id, Name: wiki.Name, Hash: hash(wiki.Content), Updated: string(wiki.Updated)})
+	case Push:
+		content, err := s.read(change.Name)
+		if err != nil {
+			return err
+		}
+		// Backlog does not accept the empty content, and the update ignores it.
+		if strings.TrimSpace(content) == "" {
+			return fmt.Errorf("wikisync: %s is empty, remove the file to delete the page", strconv.Quote(change.Name))
+		}
+
+		var wiki *backlog.Wiki
+
+		if change.WikiId == 0 {
+			wiki, err = s.client.AddWikiContext(ctx, s.project.Id, change.Name, content, s.MailNotify)
+		} else {
+			wiki, err = s.client.UpdateWikiContext(ctx, change.WikiId, change.Name, content, s.MailNotify)
+		}
+		if err != nil {
+			return err
+		}
+
+		if e := m.byId(change.WikiId); e != nil && e.Name != change.Name {
+			// The page has been renamed onto the local file, which overwrites it. The file of the old name is removed unless it has been edited, like Pull does.
+			if old, err := s.read(e.Name); err == nil && hash(old) == e.Hash {
+				if err = s.remove(e.Name); err != nil {
+					return err
+				}
+			}
+		}
+		if e := m.byName(change.Name); e != nil && e.Id != change.WikiId {
+			// The page of the same name has been deleted, and it is created again.
+			m.remove(e.Id)
+		}
+
+		m.put(entry{Id: wiki.Id, Name: change.Name, Hash: hash(content), Updated: string(wiki.Updated)})
+	case DeleteLocal:
+		if err := s.remove(change.Name); err != nil {
+			return err
+		}
+
+		m.remove(change.WikiId)
+	case DeleteRemote:
+		if _, err := s.client.DeleteWikiContext(ctx, change.WikiId, s.MailNotify); err != nil {
+			return err
+		}
+
+		m.remove(change.WikiId)
+	}
+
+	return nil
+}
+
+// scan returns the content of the local files keyed by the page name.
+func (s *Syncer) scan() (map[string]string, error) {
+	pages := map[string]string{}
+	ext := s.Ext()
+
+	err := filepath.Walk(s.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == s.dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if info.IsDir() && path != s.dir && strings.HasPrefix(info.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if info.IsDir() || filepath.Ext(path) != ext {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.dir, path)
+		if err != nil {
+			return err
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		pages[filepath.ToSlash(strings.TrimSuffix(rel, ext))] = string(data)
+
+		return nil
+	})
+
+	return pages, err
+}
+
+// path returns the local file path of the page. The page name which points outside of the directory is rejected.
+func (s *Syncer) path(name string) (string, error) {
+	rel := filepath.Clean(filepath.FromSlash(name))
+
+	if name == "" || filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("wikisync: invalid page name %s", strconv.Quote(name))
+	}
+
+	return filepath.Join(s.dir, rel+s.Ext()), nil
+}
+
+func (s *Syncer) read(name string) (string, error) {
+	path, err := s.path(name)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := ioutil.ReadFile(path)
+
+	return string(data), err
+}
+
+func (s *Syncer) write(name, content string) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, []byte(content), 0644)
+}
+
+// remove deletes the local file of the page and the directories left empty.
+func (s *Syncer) remove(name string) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	root := filepath.Clean(s.dir)
+
+	for dir := filepath.Dir(path); dir != root; dir = filepath.Dir(dir) {
+		// Remove fails if the directory is not empty.
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+
+	return nil
+}
+
+func hash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,416 @@
+package wikisync
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	backlog "github.com/moutend/go-backlog"
+)
+
+type fakeAPI struct {
+	wikis  map[int]*backlog.Wiki
+	nextId int
+	clock  int
+}
+
+func newFakeAPI() *fakeAPI {
+	return &fakeAPI{
+		wikis:  map[int]*backlog.Wiki{},
+		nextId: 1,
+	}
+}
+
+func (f *fakeAPI) tick() backlog.Date {
+	f.clock++
+
+	return backlog.Date(fmt.Sprintf("2017-08-08T00:00:%02dZ", f.clock))
+}
+
+func (f *fakeAPI) GetWikisContext(ctx context.Context, projectId string, query url.Values) ([]*backlog.Wiki, error) {
+	var wikis []*backlog.Wiki
+
+	for _, wiki := range f.wikis {
+		summary := *wiki
+		summary.Content = ""
+		wikis = append(wikis, &summary)
+	}
+
+	return wikis, nil
+}
+
+func (f *fakeAPI) GetWikiContext(ctx context.Context, wikiId int) (*backlog.Wiki, error) {
+	wiki, ok := f.wikis[wikiId]
+	if !ok {
+		return nil, backlog.Error{Message: "No wiki.", Code: 6}
+	}
+
+	return wiki, nil
+}
+
+func (f *fakeAPI) AddWikiContext(ctx context.Context, projectId int, name, content string, mailNotify bool) (*backlog.Wiki, error) {
+	wiki := &backlog.Wiki{Id: f.nextId, ProjectId: projectId, Name: name, Content: content, Updated: f.tick()}
+	f.wikis[wiki.Id] = wiki
+	f.nextId++
+
+	return wiki, nil
+}
+
+func (f *fakeAPI) UpdateWikiContext(ctx context.Context, wikiId int, name, content string, mailNotify bool) (*backlog.Wiki, error) {
+	wiki, ok := f.wikis[wikiId]
+	if !ok {
+		return nil, backlog.Error{Message: "No wiki.", Code: 6}
+	}
+	if name != "" {
+		wiki.Name = name
+	}
+	if content != "" {
+		wiki.Content = content
+	}
+
+	wiki.Updated = f.tick()
+
+	return wiki, nil
+}
+
+func (f *fakeAPI) DeleteWikiContext(ctx context.Context, wikiId int, mailNotify bool) (*backlog.Wiki, error) {
+	wiki, ok := f.wikis[wikiId]
+	if !ok {
+		return nil, backlog.Error{Message: "No wiki.", Code: 6}
+	}
+
+	delete(f.wikis, wikiId)
+
+	return wiki, nil
+}
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "wikisync")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	api := newFakeAPI()
+	api.AddWikiContext(ctx, 1, "Home", "# Home", false)
+	api.AddWikiContext(ctx, 1, "Runbooks/Deploy", "# Deploy", false)
+
+	project := &backlog.Project{Id: 1, ProjectKey: "SAMPLE", TextFormattingRule: backlog.TextFormattingRuleMarkdown}
+	syncer := New(api, project, dir)
+
+	if _, err = syncer.Pull(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "Runbooks", "Deploy.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "# Deploy" {
+		t.Fatalf("unexpected content %q", data)
+	}
+
+	// Edit the page on both sides, and edit another page locally.
+	ioutil.WriteFile(filepath.Join(dir, "Home.md"), []byte("# Home (local)"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "Runbooks", "Deploy.md"), []byte("# Deploy (local)"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "Runbooks", "Rollback.md"), []byte("# Rollback"), 0644)
+	api.UpdateWikiContext(ctx, 2, "", "# Deploy (remote)", false)
+
+	changes, err := syncer.Sync(ctx)
+	if _, ok := err.(*ConflictError); !ok {
+		t.Fatalf("expected ConflictError, got %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %v", changes)
+	}
+	if api.wikis[1].Content != "# Home (local)" {
+		t.Fatalf("unexpected content %q", api.wikis[1].Content)
+	}
+	if api.wikis[3].Name != "Runbooks/Rollback" {
+		t.Fatalf("unexpected name %q", api.wikis[3].Name)
+	}
+
+	// Nothing is left except the conflict.
+	changes, err = syncer.Plan(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Action != Conflict || changes[0].Name != "Runbooks/Deploy" {
+		t.Fatalf("unexpected changes %v", changes)
+	}
+}
+
+func TestFirstSyncIntoExistingDirectory(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "wikisync")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	api := newFakeAPI()
+	api.AddWikiContext(ctx, 1, "Home", "# Home", false)
+	api.AddWikiContext(ctx, 1, "FAQ", "# FAQ", false)
+
+	ioutil.WriteFile(filepath.Join(dir, "Home.md"), []byte("# Home"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "FAQ.md"), []byte("# FAQ (local)"), 0644)
+
+	project := &backlog.Project{Id: 1, ProjectKey: "SAMPLE", TextFormattingRule: backlog.TextFormattingRuleMarkdown}
+	syncer := New(api, project, dir)
+
+	changes, err := syncer.Sync(ctx)
+	if _, ok := err.(*ConflictError); !ok {
+		t.Fatalf("expected ConflictError, got %v", err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("expected no changes, got %v", changes)
+	}
+
+	// The identical page is recorded in the manifest, and only the differing one is left.
+	changes, err = syncer.Plan(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Action != Conflict || changes[0].Name != "FAQ" {
+		t.Fatalf("unexpected changes %v", changes)
+	}
+}
+
+func TestRenameOntoExistingFile(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "wikisync")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	api := newFakeAPI()
+	api.AddWikiContext(ctx, 1, "Draft", "# Draft", false)
+
+	project := &backlog.Project{Id: 1, ProjectKey: "SAMPLE", TextFormattingRule: backlog.TextFormattingRuleMarkdown}
+	syncer := New(api, project, dir)
+
+	if _, err = syncer.Pull(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	ioutil.WriteFile(filepath.Join(dir, "Release.md"), []byte("# Release (local)"), 0644)
+	api.UpdateWikiContext(ctx, 1, "Release", "", false)
+
+	changes, err := syncer.Sync(ctx)
+	if _, ok := err.(*ConflictError); !ok {
+		t.Fatalf("expected ConflictError, got %v", err)
+	}
+	if len(changes) != 0 || len(api.wikis) != 1 {
+		t.Fatalf("unexpected changes %v and pages %v", changes, api.wikis)
+	}
+
+	data, _ := ioutil.ReadFile(filepath.Join(dir, "Release.md"))
+	if string(data) != "# Release (local)" {
+		t.Fatalf("the local file is overwritten: %q", data)
+	}
+
+	// Keeping the local file overwrites the page with it, and the file of the old name is removed.
+	syncer.Resolve = KeepLocal
+
+	if changes, err = syncer.Sync(ctx); err != nil || len(changes) != 1 || changes[0].Action != Push {
+		t.Fatalf("unexpected changes %v and error %v", changes, err)
+	}
+	if len(api.wikis) != 1 || api.wikis[1].Content != "# Release (local)" {
+		t.Fatalf("unexpected pages %v", api.wikis)
+	}
+	if _, err = os.Stat(filepath.Join(dir, "Draft.md")); !os.IsNotExist(err) {
+		t.Fatalf("the file of the old name is left: %v", err)
+	}
+}
+
+func TestPushEmptyPage(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "wikisync")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	api := newFakeAPI()
+	api.AddWikiContext(ctx, 1, "Home", "# Home", false)
+
+	project := &backlog.Project{Id: 1, ProjectKey: "SAMPLE", TextFormattingRule: backlog.TextFormattingRuleMarkdown}
+	syncer := New(api, project, dir)
+
+	if _, err = syncer.Pull(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	ioutil.WriteFile(filepath.Join(dir, "Home.md"), nil, 0644)
+
+	if _, err = syncer.Push(ctx); err == nil {
+		t.Fatal("expected error")
+	}
+
+	// The empty file is not recorded, so it is detected again.
+	changes, err := syncer.Plan(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Action != Push {
+		t.Fatalf("unexpected changes %v", changes)
+	}
+}
+
+func TestInvalidPageName(t *testing.T) {
+	syncer := New(newFakeAPI(), &backlog.Project{}, "wiki")
+
+	if _, err := syncer.path("../outside"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestSameEditOnBothSides(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "wikisync")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	api := newFakeAPI()
+	api.AddWikiContext(ctx, 1, "Home", "# Home", false)
+
+	project := &backlog.Project{Id: 1, ProjectKey: "SAMPLE", TextFormattingRule: backlog.TextFormattingRuleMarkdown}
+	syncer := New(api, project, dir)
+
+	if _, err = syncer.Pull(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	ioutil.WriteFile(filepath.Join(dir, "Home.md"), []byte("# Home (edited)"), 0644)
+	api.UpdateWikiContext(ctx, 1, "", "# Home (edited)", false)
+
+	changes, err := syncer.Sync(ctx)
+	if err != nil || len(changes) != 0 {
+		t.Fatalf("unexpected changes %v and error %v", changes, err)
+	}
+
+	// The manifest is updated, so the next remote edit is pulled.
+	api.UpdateWikiContext(ctx, 1, "", "# Home (remote)", false)
+
+	changes, err = syncer.Plan(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Action != Pull {
+		t.Fatalf("unexpected changes %v", changes)
+	}
+}
+
+func TestRemovedFileEditedRemotely(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "wikisync")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	api := newFakeAPI()
+	api.AddWikiContext(ctx, 1, "Home", "# Home", false)
+
+	project := &backlog.Project{Id: 1, ProjectKey: "SAMPLE", TextFormattingRule: backlog.TextFormattingRuleMarkdown}
+	syncer := New(api, project, dir)
+
+	if _, err = syncer.Pull(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	os.Remove(filepath.Join(dir, "Home.md"))
+	api.UpdateWikiContext(ctx, 1, "", "# Home (remote)", false)
+
+	changes, err := syncer.Sync(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Action != Pull {
+		t.Fatalf("unexpected changes %v", changes)
+	}
+
+	data, _ := ioutil.ReadFile(filepath.Join(dir, "Home.md"))
+	if string(data) != "# Home (remote)" {
+		t.Fatalf("the file is not restored: %q", data)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	ctx := context.Background()
+
+	for _, resolve := range []Resolution{KeepLocal, KeepRemote} {
+		dir, err := ioutil.TempDir("", "wikisync")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+
+		api := newFakeAPI()
+		api.AddWikiContext(ctx, 1, "Home", "# Home", false)
+		api.AddWikiContext(ctx, 1, "FAQ", "# FAQ", false)
+		api.AddWikiContext(ctx, 1, "Draft", "# Draft", false)
+
+		ioutil.WriteFile(filepath.Join(dir, "FAQ.md"), []byte("# FAQ (local)"), 0644)
+
+		project := &backlog.Project{Id: 1, ProjectKey: "SAMPLE", TextFormattingRule: backlog.TextFormattingRuleMarkdown}
+		syncer := New(api, project, dir)
+
+		// The untracked file which differs from the page.
+		if _, err = syncer.Pull(ctx); err == nil {
+			t.Fatal("expected ConflictError")
+		}
+
+		// The page edited on both sides, and the page deleted remotely but edited locally.
+		ioutil.WriteFile(filepath.Join(dir, "Home.md"), []byte("# Home (local)"), 0644)
+		ioutil.WriteFile(filepath.Join(dir, "Draft.md"), []byte("# Draft (local)"), 0644)
+		api.UpdateWikiContext(ctx, 1, "", "# Home (remote)", false)
+		api.DeleteWikiContext(ctx, 3, false)
+
+		syncer.Resolve = resolve
+
+		changes, err := syncer.Sync(ctx)
+		if err != nil {
+			t.Fatalf("%v: %v", resolve, err)
+		}
+		if len(changes) != 3 {
+			t.Fatalf("%v: unexpected changes %v", resolve, changes)
+		}
+
+		home, _ := ioutil.ReadFile(filepath.Join(dir, "Home.md"))
+		faq, _ := ioutil.ReadFile(filepath.Join(dir, "FAQ.md"))
+		_, draftErr := os.Stat(filepath.Join(dir, "Draft.md"))
+
+		switch resolve {
+		case KeepLocal:
+			if api.wikis[1].Content != "# Home (local)" || api.wikis[2].Content != "# FAQ (local)" {
+				t.Fatalf("the local edits are not pushed: %q, %q", api.wikis[1].Content, api.wikis[2].Content)
+			}
+			if len(api.wikis) != 3 || api.wikis[4].Name != "Draft" || draftErr != nil {
+				t.Fatalf("the deleted page is not created again: %v", api.wikis)
+			}
+		case KeepRemote:
+			if string(home) != "# Home (remote)" || string(faq) != "# FAQ" {
+				t.Fatalf("the remote edits are not pulled: %q, %q", home, faq)
+			}
+			if len(api.wikis) != 2 || !os.IsNotExist(draftErr) {
+				t.Fatalf("the file of the deleted page is not removed: %v", draftErr)
+			}
+		}
+
+		// Both sides are in sync.
+		syncer.Resolve = Manual
+
+		if changes, err = syncer.Plan(ctx); err != nil || len(changes) != 0 {
+			t.Fatalf("%v: unexpected changes %v and error %v", resolve, changes, err)
+		}
+	}
+}
@@ -0,0 +1,6 @@
+package backlog
+
+type WikiTag struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}